@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"os"
+	"strings"
 
 	"github.com/sha1n/mcp-acdc-server/internal/app"
 	"github.com/spf13/cobra"
@@ -44,6 +45,32 @@ func Execute(version, build, programName string, args []string) error {
 `)
 
 	app.RegisterFlags(rootCmd.Flags())
+
+	searchCmd := &cobra.Command{
+		Use:   "search <query>",
+		Short: "Search the content directory and print ranked results",
+		Long:  "Loads and indexes the content directory exactly as the server does, then prints the ranked results of a single query.",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return app.RunSearch(cmd.Flags(), strings.Join(args, " "), cmd.OutOrStdout())
+		},
+	}
+	app.RegisterSearchFlags(searchCmd.Flags())
+	rootCmd.AddCommand(searchCmd)
+
+	validateCmd := &cobra.Command{
+		Use:          "validate",
+		Short:        "Validate the content directory",
+		Long:         "Checks metadata, frontmatter, required fields, duplicate URIs, prompt and tool names, prompt and tool templates, cross-reference links and their heading anchors. Exits with a non-zero status if any error is found.",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return app.RunValidate(cmd.Flags(), cmd.OutOrStdout())
+		},
+	}
+	app.RegisterValidateFlags(validateCmd.Flags())
+	rootCmd.AddCommand(validateCmd)
+
 	rootCmd.SetArgs(args)
 
 	return rootCmd.Execute()
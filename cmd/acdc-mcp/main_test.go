@@ -42,6 +42,32 @@ func TestExecute_Run(t *testing.T) {
 	}
 }
 
+func TestExecute_Search(t *testing.T) {
+	err := Execute("test", "test", "test", []string{"search", "--content-dir", "../../examples/sample-content", "search"})
+	if err != nil {
+		t.Errorf("Execute search failed: %v", err)
+	}
+}
+
+func TestExecute_SearchMissingQuery(t *testing.T) {
+	err := Execute("test", "test", "test", []string{"search"})
+	if err == nil {
+		t.Error("Expected error for missing query")
+	}
+}
+
+func TestExecute_Validate(t *testing.T) {
+	err := Execute("test", "test", "test", []string{"validate", "--content-dir", "../../examples/sample-content"})
+	if err != nil {
+		t.Errorf("Execute validate failed: %v", err)
+	}
+
+	err = Execute("test", "test", "test", []string{"validate", "--content-dir", t.TempDir()})
+	if err == nil {
+		t.Error("Expected validation error for empty content dir")
+	}
+}
+
 func TestRunMain(t *testing.T) {
 	exitCode := -1
 	exit := func(code int) {
@@ -2,20 +2,58 @@ package app
 
 import "github.com/spf13/pflag"
 
+// Output format constants for offline subcommands
+const (
+	OutputFormatTable = "table"
+	OutputFormatText  = "text"
+	OutputFormatJSON  = "json"
+	OutputFormatSARIF = "sarif"
+)
+
 // RegisterFlags registers all CLI flags on the given FlagSet
 func RegisterFlags(flags *pflag.FlagSet) {
+	flags.StringP("transport", "t", "", "Transport type: stdio, sse, streamable-http or http (both sse and streamable-http) (default: stdio)")
+	flags.StringP("host", "H", "", "Host for HTTP transports (default: 0.0.0.0)")
+	flags.IntP("port", "p", 0, "Port for HTTP transports (default: 8080)")
+	flags.Duration("session-timeout", 0, "Idle timeout for Streamable HTTP sessions, 0 disables (default: 30m)")
+	flags.Bool("prompt-tools", false, "Also expose every prompt as a tool for clients without prompt support (default: false)")
+	flags.Int("read-many-max-kb", 0, "Maximum total size in KB of the resources returned by one read_many call, 0 disables the limit (default: 256)")
+	RegisterContentFlags(flags)
+	flags.StringP("auth-type", "a", "", "Authentication type: none, basic, or apikey (default: none)")
+	flags.StringP("auth-basic-username", "u", "", "Basic auth username")
+	flags.StringP("auth-basic-password", "P", "", "Basic auth password")
+	flags.StringSliceP("auth-api-keys", "k", nil, "API keys (comma-separated)")
+}
+
+// RegisterContentFlags registers the flags that control how content is loaded,
+// indexed and searched. They are shared by the server and the offline subcommands.
+func RegisterContentFlags(flags *pflag.FlagSet) {
 	flags.StringP("content-dir", "c", "", "Path to content directory (default: ./content)")
-	flags.StringP("transport", "t", "", "Transport type: stdio or sse (default: stdio)")
-	flags.StringP("host", "H", "", "Host for SSE transport (default: 0.0.0.0)")
-	flags.IntP("port", "p", 0, "Port for SSE transport (default: 8080)")
 	flags.IntP("search-max-results", "m", 0, "Maximum search results (default: 10)")
 	flags.Float64("search-keywords-boost", 0, "Boost for keywords matches (default: 3.0)")
 	flags.Float64("search-name-boost", 0, "Boost for name matches (default: 2.0)")
 	flags.Float64("search-content-boost", 0, "Boost for content matches (default: 1.0)")
+	flags.Bool("search-explain", false, "Include score explanations in search results (default: false)")
+	flags.Float64("search-priority-multiplier", 0, "Score multiplier per unit of frontmatter priority (default: 0, disabled)")
+	flags.Float64("search-recency-half-life-days", 0, "Days after which the score of a resource with an 'updated' date halves (default: 0, disabled)")
+	flags.Float64("search-deprecation-penalty", 0, "Fraction of score removed from deprecated resources, 0-1 (default: 0, disabled)")
 	flags.StringP("uri-scheme", "s", "", "URI scheme for resources (default: acdc)")
 	flags.Bool("cross-ref", false, "Transform relative markdown links to resource URIs (default: false)")
-	flags.StringP("auth-type", "a", "", "Authentication type: none, basic, or apikey (default: none)")
-	flags.StringP("auth-basic-username", "u", "", "Basic auth username")
-	flags.StringP("auth-basic-password", "P", "", "Basic auth password")
-	flags.StringSliceP("auth-api-keys", "k", nil, "API keys (comma-separated)")
+	flags.Bool("cross-ref-mark-broken", false, "With --cross-ref, replace links that do not resolve with a visible broken link marker (default: false)")
+	flags.Bool("strict", false, "Fail on invalid or skipped content instead of logging a warning (default: false)")
+}
+
+// RegisterSearchFlags registers the flags of the search subcommand
+func RegisterSearchFlags(flags *pflag.FlagSet) {
+	RegisterContentFlags(flags)
+	flags.IntP("limit", "l", 0, "Maximum number of results (default: search-max-results)")
+	flags.String("uri-prefix", "", "Only return resources whose URI starts with this prefix")
+	flags.StringP("output", "o", OutputFormatTable, "Output format: table or json")
+}
+
+// RegisterValidateFlags registers the flags of the validate subcommand
+func RegisterValidateFlags(flags *pflag.FlagSet) {
+	flags.StringP("content-dir", "c", "", "Path to content directory (default: ./content)")
+	flags.StringP("uri-scheme", "s", "", "URI scheme for resources (default: acdc)")
+	flags.StringP("output", "o", OutputFormatText, "Output format: text, json or sarif")
 }
@@ -112,3 +112,24 @@ func TestCLI_FlagParsing_AuthAPIKeys(t *testing.T) {
 		t.Errorf("Unexpected keys: %v", keys)
 	}
 }
+
+func TestRegisterSearchFlags(t *testing.T) {
+	flags := pflag.NewFlagSet("search", pflag.ContinueOnError)
+	RegisterSearchFlags(flags)
+
+	for _, name := range []string{"content-dir", "search-max-results", "search-name-boost", "limit", "uri-prefix", "output"} {
+		if flags.Lookup(name) == nil {
+			t.Errorf("Flag --%s not registered", name)
+		}
+	}
+	for _, name := range []string{"transport", "port", "auth-type"} {
+		if flags.Lookup(name) != nil {
+			t.Errorf("Server flag --%s should not be registered on search", name)
+		}
+	}
+
+	output, _ := flags.GetString("output")
+	if output != OutputFormatTable {
+		t.Errorf("Expected default output %q, got %q", OutputFormatTable, output)
+	}
+}
@@ -3,6 +3,7 @@ package app
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"os"
 
 	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
@@ -13,52 +14,118 @@ import (
 	"github.com/sha1n/mcp-acdc-server/internal/prompts"
 	"github.com/sha1n/mcp-acdc-server/internal/resources"
 	"github.com/sha1n/mcp-acdc-server/internal/search"
+	"github.com/sha1n/mcp-acdc-server/internal/tools"
 	"gopkg.in/yaml.v3"
 )
 
+const metadataFileName = "mcp-metadata.yaml"
+
+// Content holds the services built from a content directory
+type Content struct {
+	Metadata         domain.McpMetadata
+	ResourceProvider *resources.ResourceProvider
+	PromptProvider   *prompts.PromptProvider
+	ToolProvider     *tools.ToolProvider
+	SearchService    *search.Service
+}
+
 // CreateMCPServer initializes the core MCP server components
 func CreateMCPServer(settings *config.Settings) (*mcpsdk.Server, func(), error) {
+	c, cleanup, err := LoadContent(settings)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Create MCP server
+	mcpServer := mcp.CreateServer(c.Metadata, c.ResourceProvider, c.PromptProvider, c.SearchService,
+		mcp.WithCustomTools(c.ToolProvider),
+		mcp.WithPromptTools(settings.PromptTools),
+		mcp.WithReadManyMaxSize(settings.ReadManyMaxKB*1024),
+	)
+
+	return mcpServer, cleanup, nil
+}
+
+// LoadContent loads metadata, discovers resources and prompts and indexes the
+// content directory described by settings. The returned cleanup function
+// releases the search index.
+func LoadContent(settings *config.Settings) (*Content, func(), error) {
 	// Initialize content provider
 	cp := content.NewContentProvider(settings.ContentDir)
 
 	// Load metadata
-	metadataPath := cp.GetPath("mcp-metadata.yaml")
-
-	mdBytes, err := os.ReadFile(metadataPath)
+	metadata, err := loadMetadata(cp)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to read metadata file: %w", err)
-	}
-
-	var metadata domain.McpMetadata
-	if err := yaml.Unmarshal(mdBytes, &metadata); err != nil {
-		return nil, nil, fmt.Errorf("failed to parse metadata: %w", err)
-	}
-
-	if err := metadata.Validate(); err != nil {
-		return nil, nil, fmt.Errorf("metadata validation failed: %w", err)
+		return nil, nil, err
 	}
 
 	// Discover resources
-	resourceDefinitions, err := resources.DiscoverResources(cp, settings.Scheme)
+	resourceDefinitions, issues, err := resources.DiscoverResourcesWithIssues(cp, settings.Scheme)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to discover resources: %w", err)
 	}
 
-	var resourceOpts []resources.Option
+	templateDefinitions, templateIssues, err := resources.DiscoverResourceTemplatesWithIssues(cp)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to discover resource templates: %w", err)
+	}
+	issues = append(issues, templateIssues...)
+	templateDefinitions = append(resources.TemplatesFromMetadata(metadata.ResourceTemplates), templateDefinitions...)
+
+	// Includes are expanded first so that cross-references in included
+	// content are rewritten too
+	resourceOpts := []resources.Option{
+		resources.WithTemplates(templateDefinitions),
+		resources.WithTransformer(resources.NewIncludeTransformer(cp.ResourcesDir)),
+	}
+	var linkIssues []domain.Issue
 	if settings.CrossRef {
+		linkIssues = resources.FindUnresolvedLinks(resourceDefinitions, settings.Scheme, cp.ResourcesDir)
 		resourceOpts = append(resourceOpts, resources.WithTransformer(
-			resources.NewCrossRefTransformer(resourceDefinitions, settings.Scheme),
+			resources.NewCrossRefTransformer(resourceDefinitions, settings.Scheme,
+				resources.WithBrokenLinkMarkers(settings.CrossRefMarkBroken)),
 		))
 	}
 	resourceProvider := resources.NewResourceProvider(resourceDefinitions, resourceOpts...)
 
 	// Discover prompts
-	promptDefinitions, err := prompts.DiscoverPrompts(cp)
+	promptDefinitions, promptIssues, err := prompts.DiscoverPromptsWithIssues(cp)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to discover prompts: %w", err)
 	}
+	issues = append(issues, promptIssues...)
+
+	// Discover custom tools
+	toolDefinitions, err := tools.FromMetadata(metadata.Tools)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load custom tools: %w", err)
+	}
+	fileToolDefinitions, toolIssues, err := tools.DiscoverToolsWithIssues(cp, toolDefinitions, metadata.ExposedBuiltinToolNames())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to discover tools: %w", err)
+	}
+	issues = append(issues, toolIssues...)
+	toolDefinitions = append(toolDefinitions, fileToolDefinitions...)
 
-	promptProvider := prompts.NewPromptProvider(promptDefinitions, cp)
+	// In strict mode, refuse to serve partial content. Like validate, only
+	// errors fail; warnings are logged.
+	if settings.Strict {
+		issues = append(issues, resources.FindUnresolvedIncludes(resourceDefinitions, cp.ResourcesDir)...)
+		issues = append(issues, linkIssues...)
+		var errs []domain.Issue
+		for _, issue := range issues {
+			if issue.Severity == domain.SeverityError {
+				issue.File = relativePath(settings.ContentDir, issue.File)
+				errs = append(errs, issue)
+			}
+		}
+		if len(errs) > 0 {
+			return nil, nil, fmt.Errorf("strict mode: %w", &domain.IssuesError{Issues: errs})
+		}
+	}
+	for _, issue := range linkIssues {
+		slog.Warn("Broken cross-reference", "file", relativePath(settings.ContentDir, issue.File), "rule", issue.Rule, "error", issue.Message)
+	}
 
 	// Initialize search service
 	searchService := search.NewService(settings.Search)
@@ -66,11 +133,54 @@ func CreateMCPServer(settings *config.Settings) (*mcpsdk.Server, func(), error)
 		searchService.Close()
 	}
 
+	promptProvider := prompts.NewPromptProvider(promptDefinitions, cp,
+		prompts.WithResources(resourceProvider),
+		prompts.WithSearcher(searchService),
+	)
+
+	toolProvider := tools.NewToolProvider(toolDefinitions,
+		tools.WithResources(resourceProvider),
+		tools.WithSearcher(searchService),
+	)
+
+	// Render templated server instructions against the discovered content
+	if metadata.Server.InstructionsTemplate {
+		instructionsData := mcp.NewInstructionsData(metadata, resourceProvider, promptProvider)
+		metadata.Server.Instructions, err = mcp.RenderInstructions(metadata.Server.Instructions, instructionsData)
+		if err != nil {
+			cleanup()
+			return nil, nil, fmt.Errorf("failed to render server instructions: %w", err)
+		}
+	}
+
 	// Index resources
 	IndexResources(context.Background(), resourceProvider, searchService)
 
-	// Create MCP server
-	mcpServer := mcp.CreateServer(metadata, resourceProvider, promptProvider, searchService)
+	return &Content{
+		Metadata:         metadata,
+		ResourceProvider: resourceProvider,
+		PromptProvider:   promptProvider,
+		ToolProvider:     toolProvider,
+		SearchService:    searchService,
+	}, cleanup, nil
+}
 
-	return mcpServer, cleanup, nil
+// loadMetadata reads, parses and validates mcp-metadata.yaml
+func loadMetadata(cp *content.ContentProvider) (domain.McpMetadata, error) {
+	var metadata domain.McpMetadata
+
+	mdBytes, err := os.ReadFile(cp.GetPath(metadataFileName))
+	if err != nil {
+		return metadata, fmt.Errorf("failed to read metadata file: %w", err)
+	}
+
+	if err := yaml.Unmarshal(mdBytes, &metadata); err != nil {
+		return metadata, fmt.Errorf("failed to parse metadata: %w", err)
+	}
+
+	if err := metadata.Validate(); err != nil {
+		return metadata, fmt.Errorf("metadata validation failed: %w", err)
+	}
+
+	return metadata, nil
 }
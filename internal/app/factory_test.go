@@ -1,6 +1,7 @@
 package app
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -8,6 +9,7 @@ import (
 
 	"github.com/sha1n/mcp-acdc-server/internal/config"
 	"github.com/sha1n/mcp-acdc-server/internal/content"
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
 	"github.com/sha1n/mcp-acdc-server/internal/resources"
 )
 
@@ -485,3 +487,91 @@ func TestCreateMCPServer_CrossRefTransformation_CustomScheme(t *testing.T) {
 		t.Errorf("Content should contain 'myco://b', got: %s", contentA)
 	}
 }
+
+func TestCreateMCPServer_StrictMode(t *testing.T) {
+	tempDir := t.TempDir()
+	contentDir := filepath.Join(tempDir, "content")
+	resourcesDir := filepath.Join(contentDir, "mcp-resources")
+	promptsDir := filepath.Join(contentDir, "mcp-prompts")
+	_ = os.MkdirAll(resourcesDir, 0755)
+	_ = os.MkdirAll(promptsDir, 0755)
+
+	metadataContent := `
+server:
+  name: test
+  version: 1.0
+  instructions: inst
+`
+	_ = os.WriteFile(filepath.Join(contentDir, "mcp-metadata.yaml"), []byte(metadataContent), 0644)
+	_ = os.WriteFile(filepath.Join(resourcesDir, "valid.md"), []byte("---\nname: Valid\ndescription: D\n---\nSee [gone](gone.md)"), 0644)
+	_ = os.WriteFile(filepath.Join(resourcesDir, "invalid.md"), []byte("---\n: broken\n---\ncontent"), 0644)
+	_ = os.WriteFile(filepath.Join(promptsDir, "a.md"), []byte("---\nname: p\ndescription: d\n---\nA"), 0644)
+	_ = os.WriteFile(filepath.Join(promptsDir, "b.md"), []byte("---\nname: p\ndescription: d\n---\nB"), 0644)
+
+	newSettings := func(strict, crossRef bool) *config.Settings {
+		return &config.Settings{
+			ContentDir: contentDir,
+			Scheme:     "acdc",
+			CrossRef:   crossRef,
+			Strict:     strict,
+			Search: config.SearchSettings{
+				InMemory:   true,
+				MaxResults: 10,
+			},
+		}
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		_, cleanup, err := CreateMCPServer(newSettings(false, true))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		cleanup()
+	})
+
+	t.Run("AggregatesAllProblems", func(t *testing.T) {
+		_, _, err := CreateMCPServer(newSettings(true, true))
+		if err == nil {
+			t.Fatal("Expected strict mode error")
+		}
+
+		var issuesErr *domain.IssuesError
+		if !errors.As(err, &issuesErr) {
+			t.Fatalf("Expected IssuesError, got %T: %v", err, err)
+		}
+		if len(issuesErr.Issues) != 3 {
+			t.Errorf("Expected 3 issues, got %+v", issuesErr.Issues)
+		}
+		for _, want := range []string{"mcp-resources/invalid.md", "mcp-prompts/b.md", `link "gone.md"`} {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("Expected error to mention %q, got: %v", want, err)
+			}
+		}
+	})
+
+	t.Run("LinksIgnoredWithoutCrossRef", func(t *testing.T) {
+		_, _, err := CreateMCPServer(newSettings(true, false))
+		var issuesErr *domain.IssuesError
+		if !errors.As(err, &issuesErr) {
+			t.Fatalf("Expected IssuesError, got %v", err)
+		}
+		if len(issuesErr.Issues) != 2 {
+			t.Errorf("Expected 2 issues, got %+v", issuesErr.Issues)
+		}
+	})
+
+	t.Run("WarningsDoNotFail", func(t *testing.T) {
+		warnDir := filepath.Join(tempDir, "warnings")
+		_ = os.MkdirAll(filepath.Join(warnDir, "mcp-resources"), 0755)
+		_ = os.WriteFile(filepath.Join(warnDir, "mcp-metadata.yaml"), []byte(metadataContent), 0644)
+		_ = os.WriteFile(filepath.Join(warnDir, "mcp-resources", "guide.md"), []byte("---\nname: Guide\ndescription: D\n---\nSee [steps](#missing-heading)"), 0644)
+
+		settings := newSettings(true, true)
+		settings.ContentDir = warnDir
+		_, cleanup, err := CreateMCPServer(settings)
+		if err != nil {
+			t.Fatalf("Expected warnings not to fail strict mode, got: %v", err)
+		}
+		cleanup()
+	})
+}
@@ -15,7 +15,7 @@ import (
 type RunParams struct {
 	LoadSettings      func(*pflag.FlagSet) (*config.Settings, error)
 	ValidSettings     func(*config.Settings) error
-	StartSSEServer    func(*mcp.Server, *config.Settings) error
+	StartHTTPServer   func(*mcp.Server, *config.Settings) error
 	CreateServer      func(*config.Settings) (*mcp.Server, func(), error)
 	CustomIOTransport mcp.Transport // Optional: for testing with custom IO
 }
@@ -23,10 +23,10 @@ type RunParams struct {
 // DefaultRunParams returns production dependencies
 func DefaultRunParams() RunParams {
 	return RunParams{
-		LoadSettings:   config.LoadSettingsWithFlags,
-		ValidSettings:  config.ValidateSettings,
-		StartSSEServer: StartSSEServer,
-		CreateServer:   CreateMCPServer,
+		LoadSettings:    config.LoadSettingsWithFlags,
+		ValidSettings:   config.ValidateSettings,
+		StartHTTPServer: StartHTTPServer,
+		CreateServer:    CreateMCPServer,
 	}
 }
 
@@ -59,7 +59,7 @@ func RunWithDeps(ctx context.Context, params RunParams, flags *pflag.FlagSet, ve
 	}
 
 	// Start server
-	if settings.Transport == "stdio" {
+	if settings.Transport == config.TransportStdio {
 		// Use custom transport if provided (for testing), otherwise use stdio
 		transport := params.CustomIOTransport
 		if transport == nil {
@@ -67,7 +67,7 @@ func RunWithDeps(ctx context.Context, params RunParams, flags *pflag.FlagSet, ve
 		}
 		return mcpServer.Run(ctx, transport)
 	} else {
-		slog.Info("Starting SSE server", "host", settings.Host, "port", settings.Port)
-		return params.StartSSEServer(mcpServer, settings)
+		slog.Info("Starting HTTP server", "transport", settings.Transport, "host", settings.Host, "port", settings.Port)
+		return params.StartHTTPServer(mcpServer, settings)
 	}
 }
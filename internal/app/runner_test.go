@@ -58,7 +58,7 @@ func TestRunWithDeps_ErrorCases(t *testing.T) {
 			wantErrContain: "create server error",
 		},
 		{
-			name: "StartSSEServer error",
+			name: "StartHTTPServer error",
 			params: RunParams{
 				LoadSettings: func(*pflag.FlagSet) (*config.Settings, error) {
 					return &config.Settings{Transport: "sse"}, nil
@@ -67,7 +67,7 @@ func TestRunWithDeps_ErrorCases(t *testing.T) {
 				CreateServer: func(*config.Settings) (*mcp.Server, func(), error) {
 					return nil, nil, nil
 				},
-				StartSSEServer: func(*mcp.Server, *config.Settings) error {
+				StartHTTPServer: func(*mcp.Server, *config.Settings) error {
 					return errors.New("sse start error")
 				},
 			},
@@ -98,7 +98,7 @@ func TestRunWithDeps_Cleanup(t *testing.T) {
 		CreateServer: func(*config.Settings) (*mcp.Server, func(), error) {
 			return nil, func() { cleanupCalled = true }, nil
 		},
-		StartSSEServer: func(*mcp.Server, *config.Settings) error {
+		StartHTTPServer: func(*mcp.Server, *config.Settings) error {
 			return errors.New("intentional error to trigger cleanup")
 		},
 	}
@@ -119,8 +119,8 @@ func TestDefaultRunParams(t *testing.T) {
 	if params.ValidSettings == nil {
 		t.Error("ValidSettings is nil")
 	}
-	if params.StartSSEServer == nil {
-		t.Error("StartSSEServer is nil")
+	if params.StartHTTPServer == nil {
+		t.Error("StartHTTPServer is nil")
 	}
 	if params.CreateServer == nil {
 		t.Error("CreateServer is nil")
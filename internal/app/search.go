@@ -0,0 +1,113 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"text/tabwriter"
+
+	"github.com/sha1n/mcp-acdc-server/internal/config"
+	"github.com/sha1n/mcp-acdc-server/internal/search"
+	"github.com/spf13/pflag"
+)
+
+// searchResultOutput is the JSON representation of a search result
+type searchResultOutput struct {
+	Rank        int                 `json:"rank"`
+	URI         string              `json:"uri"`
+	Name        string              `json:"name"`
+	Score       float64             `json:"score"`
+	Snippet     string              `json:"snippet"`
+	Explanation *search.Explanation `json:"explanation,omitempty"`
+}
+
+// RunSearch loads the content directory exactly as the server does, runs a
+// single query and writes the ranked results to out.
+func RunSearch(flags *pflag.FlagSet, query string, out io.Writer) error {
+	settings, err := config.LoadSettingsWithFlags(flags)
+	if err != nil {
+		return fmt.Errorf("failed to load settings: %w", err)
+	}
+	if err := config.ValidateSettings(settings); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	limit, _ := flags.GetInt("limit")
+	uriPrefix, _ := flags.GetString("uri-prefix")
+	output, _ := flags.GetString("output")
+	if output != OutputFormatTable && output != OutputFormatJSON {
+		return fmt.Errorf("output must be '%s' or '%s', got: %s", OutputFormatTable, OutputFormatJSON, output)
+	}
+
+	// Keep stdout clean for results; only surface warnings and errors
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn})))
+
+	// Indexing is a one-off, keep it in memory
+	settings.Search.InMemory = true
+	c, cleanup, err := LoadContent(settings)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	opts := &search.SearchOptions{
+		URIPrefix: uriPrefix,
+		Explain:   settings.Search.Explain,
+	}
+	if limit > 0 {
+		opts.Limit = &limit
+	}
+
+	results, err := c.SearchService.Search(query, opts)
+	if err != nil {
+		return err
+	}
+
+	if output == OutputFormatJSON {
+		return writeSearchResultsJSON(out, results)
+	}
+	return writeSearchResultsTable(out, results)
+}
+
+func writeSearchResultsJSON(out io.Writer, results []search.SearchResult) error {
+	outputs := make([]searchResultOutput, len(results))
+	for i, r := range results {
+		outputs[i] = searchResultOutput{
+			Rank:        i + 1,
+			URI:         r.URI,
+			Name:        r.Name,
+			Score:       r.Score,
+			Snippet:     r.Snippet,
+			Explanation: r.Explanation,
+		}
+	}
+
+	return writeJSON(out, outputs)
+}
+
+func writeSearchResultsTable(out io.Writer, results []search.SearchResult) error {
+	if len(results) == 0 {
+		_, err := fmt.Fprintln(out, "No results found")
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "RANK\tSCORE\tURI\tNAME")
+	for i, r := range results {
+		_, _ = fmt.Fprintf(w, "%d\t%.4f\t%s\t%s\n", i+1, r.Score, r.URI, r.Name)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	for i, r := range results {
+		if r.Explanation == nil {
+			continue
+		}
+		if _, err := fmt.Fprintf(out, "\n#%d %s\n%s\n", i+1, r.URI, r.Explanation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func writeSearchTestContent(t *testing.T) string {
+	t.Helper()
+	contentDir := t.TempDir()
+	resourcesDir := filepath.Join(contentDir, "mcp-resources")
+	_ = os.MkdirAll(filepath.Join(resourcesDir, "guides"), 0755)
+
+	metadataContent := `
+server:
+  name: test
+  version: 1.0
+  instructions: inst
+`
+	_ = os.WriteFile(filepath.Join(contentDir, "mcp-metadata.yaml"), []byte(metadataContent), 0644)
+	_ = os.WriteFile(filepath.Join(resourcesDir, "guides", "deploy.md"),
+		[]byte("---\nname: Deploy Guide\ndescription: How to deploy\nkeywords: [deployment]\n---\nDeployment steps"), 0644)
+	_ = os.WriteFile(filepath.Join(resourcesDir, "overview.md"),
+		[]byte("---\nname: Overview\ndescription: Overview\n---\nThe deployment pipeline at a glance"), 0644)
+	return contentDir
+}
+
+func runSearchWithArgs(t *testing.T, query string, args ...string) (string, error) {
+	t.Helper()
+	flags := pflag.NewFlagSet("search", pflag.ContinueOnError)
+	RegisterSearchFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	var out bytes.Buffer
+	err := RunSearch(flags, query, &out)
+	return out.String(), err
+}
+
+func TestRunSearch_Table(t *testing.T) {
+	contentDir := writeSearchTestContent(t)
+
+	out, err := runSearchWithArgs(t, "deployment", "--content-dir", contentDir)
+	if err != nil {
+		t.Fatalf("RunSearch failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected header and 2 rows, got:\n%s", out)
+	}
+	if !strings.HasPrefix(lines[0], "RANK") || !strings.Contains(lines[0], "URI") {
+		t.Errorf("Unexpected header: %s", lines[0])
+	}
+	if !strings.Contains(lines[1], "acdc://guides/deploy") {
+		t.Errorf("Expected keyword match first, got: %s", lines[1])
+	}
+}
+
+func TestRunSearch_JSONWithOptions(t *testing.T) {
+	contentDir := writeSearchTestContent(t)
+
+	out, err := runSearchWithArgs(t, "deployment",
+		"--content-dir", contentDir, "--output", "json", "--limit", "1", "--uri-prefix", "acdc://guides/", "--search-explain")
+	if err != nil {
+		t.Fatalf("RunSearch failed: %v", err)
+	}
+
+	var results []searchResultOutput
+	if err := json.Unmarshal([]byte(out), &results); err != nil {
+		t.Fatalf("Invalid JSON output: %v\n%s", err, out)
+	}
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 result, got %d", len(results))
+	}
+	if results[0].Rank != 1 || results[0].URI != "acdc://guides/deploy" {
+		t.Errorf("Unexpected result: %+v", results[0])
+	}
+	if results[0].Explanation == nil {
+		t.Error("Expected explanation with --search-explain")
+	}
+}
+
+func TestRunSearch_NoResults(t *testing.T) {
+	contentDir := writeSearchTestContent(t)
+
+	out, err := runSearchWithArgs(t, "zzzzzzzz", "--content-dir", contentDir)
+	if err != nil {
+		t.Fatalf("RunSearch failed: %v", err)
+	}
+	if !strings.Contains(out, "No results found") {
+		t.Errorf("Expected no results message, got: %s", out)
+	}
+}
+
+func TestRunSearch_Errors(t *testing.T) {
+	contentDir := writeSearchTestContent(t)
+
+	if _, err := runSearchWithArgs(t, "deployment", "--content-dir", contentDir, "--output", "xml"); err == nil {
+		t.Error("Expected error for unknown output format")
+	}
+	if _, err := runSearchWithArgs(t, "deployment", "--content-dir", filepath.Join(contentDir, "missing")); err == nil {
+		t.Error("Expected error for missing content dir")
+	}
+	if _, err := runSearchWithArgs(t, "deployment", "--content-dir", contentDir, "--search-deprecation-penalty", "2"); err == nil {
+		t.Error("Expected error for invalid settings")
+	}
+}
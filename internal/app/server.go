@@ -10,31 +10,48 @@ import (
 	"github.com/sha1n/mcp-acdc-server/internal/config"
 )
 
-// StartSSEServer starts the SSE server with authentication
-func StartSSEServer(s *mcp.Server, settings *config.Settings) error {
-	srv, err := NewSSEServer(s, settings)
+// HTTP endpoint paths
+const (
+	HealthPath         = "/health"
+	SSEPath            = "/sse"
+	StreamableHTTPPath = "/mcp"
+)
+
+// StartHTTPServer starts the HTTP server with authentication
+func StartHTTPServer(s *mcp.Server, settings *config.Settings) error {
+	srv, err := NewHTTPServer(s, settings)
 	if err != nil {
 		return err
 	}
 
-	slog.Info("Server listening (HTTP)", "addr", srv.Addr, "auth_type", settings.Auth.Type)
+	slog.Info("Server listening (HTTP)", "addr", srv.Addr, "transport", settings.Transport, "auth_type", settings.Auth.Type)
 	return srv.ListenAndServe()
 }
 
-// NewSSEServer creates a new SSE server with authentication middleware
-func NewSSEServer(s *mcp.Server, settings *config.Settings) (*http.Server, error) {
+// NewHTTPServer creates a new HTTP server with authentication middleware.
+// The SSE endpoint is served for the sse transport, the Streamable HTTP
+// endpoint for the streamable-http transport, and both for the http transport.
+func NewHTTPServer(s *mcp.Server, settings *config.Settings) (*http.Server, error) {
 	// Factory function returns the server instance for each request
-	sseHandler := mcp.NewSSEHandler(func(r *http.Request) *mcp.Server {
+	getServer := func(r *http.Request) *mcp.Server {
 		return s
-	}, nil)
+	}
 
 	mux := http.NewServeMux()
-	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+	mux.HandleFunc(HealthPath, func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("ok"))
 	})
-	mux.Handle("/sse", sseHandler)
+
+	if settings.Transport != config.TransportStreamableHTTP {
+		mux.Handle(SSEPath, mcp.NewSSEHandler(getServer, nil))
+	}
+	if settings.Transport == config.TransportStreamableHTTP || settings.Transport == config.TransportHTTP {
+		mux.Handle(StreamableHTTPPath, mcp.NewStreamableHTTPHandler(getServer, &mcp.StreamableHTTPOptions{
+			SessionTimeout: settings.SessionTimeout,
+		}))
+	}
 
 	authMiddleware, err := auth.NewMiddleware(settings.Auth)
 	if err != nil {
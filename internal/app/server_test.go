@@ -2,13 +2,15 @@ package app
 
 import (
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/sha1n/mcp-acdc-server/internal/config"
 )
 
-func TestNewSSEServer(t *testing.T) {
+func TestNewHTTPServer(t *testing.T) {
 	tests := []struct {
 		name     string
 		settings *config.Settings
@@ -66,7 +68,7 @@ func TestNewSSEServer(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mcpSrv := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0"}, nil)
-			srv, err := NewSSEServer(mcpSrv, tt.settings)
+			srv, err := NewHTTPServer(mcpSrv, tt.settings)
 
 			if tt.wantErr {
 				if err == nil {
@@ -76,7 +78,7 @@ func TestNewSSEServer(t *testing.T) {
 			}
 
 			if err != nil {
-				t.Fatalf("NewSSEServer failed: %v", err)
+				t.Fatalf("NewHTTPServer failed: %v", err)
 			}
 			if srv == nil {
 				t.Fatal("Expected non-nil server")
@@ -88,18 +90,18 @@ func TestNewSSEServer(t *testing.T) {
 	}
 }
 
-func TestStartSSEServer_NewSSEServerError(t *testing.T) {
+func TestStartHTTPServer_NewHTTPServerError(t *testing.T) {
 	mcpSrv := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0"}, nil)
 	settings := &config.Settings{
 		Auth: config.AuthSettings{Type: "invalid"},
 	}
-	err := StartSSEServer(mcpSrv, settings)
+	err := StartHTTPServer(mcpSrv, settings)
 	if err == nil {
 		t.Error("Expected error for invalid auth type")
 	}
 }
 
-func TestStartSSEServer_PortCollision(t *testing.T) {
+func TestStartHTTPServer_PortCollision(t *testing.T) {
 	mcpSrv := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0"}, nil)
 
 	// Bind to a port
@@ -116,8 +118,45 @@ func TestStartSSEServer_PortCollision(t *testing.T) {
 		Auth: config.AuthSettings{Type: config.AuthTypeNone},
 	}
 
-	err = StartSSEServer(mcpSrv, settings)
+	err = StartHTTPServer(mcpSrv, settings)
 	if err == nil {
 		t.Error("Expected error because port is already in use")
 	}
 }
+
+func TestNewHTTPServer_TransportEndpoints(t *testing.T) {
+	tests := []struct {
+		transport string
+		wantSSE   bool
+		wantMCP   bool
+	}{
+		{config.TransportSSE, true, false},
+		{config.TransportStreamableHTTP, false, true},
+		{config.TransportHTTP, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.transport, func(t *testing.T) {
+			mcpSrv := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0"}, nil)
+			srv, err := NewHTTPServer(mcpSrv, &config.Settings{
+				Transport: tt.transport,
+				Auth:      config.AuthSettings{Type: config.AuthTypeNone},
+			})
+			if err != nil {
+				t.Fatalf("NewHTTPServer failed: %v", err)
+			}
+
+			mux := srv.Handler
+			for path, want := range map[string]bool{SSEPath: tt.wantSSE, StreamableHTTPPath: tt.wantMCP, HealthPath: true} {
+				// Unsupported methods are rejected by both MCP handlers without opening a stream
+				req := httptest.NewRequest(http.MethodPut, path, nil)
+				rec := httptest.NewRecorder()
+				mux.ServeHTTP(rec, req)
+
+				if served := rec.Code != http.StatusNotFound; served != want {
+					t.Errorf("%s: expected served=%v, got status %d", path, want, rec.Code)
+				}
+			}
+		})
+	}
+}
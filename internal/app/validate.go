@@ -0,0 +1,283 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sha1n/mcp-acdc-server/internal/config"
+	"github.com/sha1n/mcp-acdc-server/internal/content"
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+	"github.com/sha1n/mcp-acdc-server/internal/mcp"
+	"github.com/sha1n/mcp-acdc-server/internal/prompts"
+	"github.com/sha1n/mcp-acdc-server/internal/resources"
+	"github.com/sha1n/mcp-acdc-server/internal/tools"
+	"github.com/spf13/pflag"
+)
+
+// ErrValidationFailed is returned by RunValidate when error issues are found
+var ErrValidationFailed = errors.New("content validation failed")
+
+// ruleDescriptions describes each issue rule for SARIF output
+var ruleDescriptions = map[string]string{
+	domain.RuleMetadata:         "mcp-metadata.yaml is missing, unparsable or incomplete",
+	domain.RuleFrontmatter:      "File frontmatter is missing or is not valid YAML",
+	domain.RuleRequiredField:    "A required frontmatter field is missing or empty",
+	domain.RuleDuplicateURI:     "Two resources resolve to the same URI",
+	domain.RuleDuplicatePrompt:  "Two prompts share the same name",
+	domain.RuleDuplicateTool:    "A custom tool name is already used by another tool",
+	domain.RuleTemplate:         "Prompt or resource template cannot be parsed",
+	domain.RuleUnresolvedLink:   "Relative link does not resolve to a resource",
+	domain.RuleMissingAnchor:    "Link fragment does not match a heading of the linked resource",
+	domain.RuleAttachment:       "Resource attachment is invalid or missing",
+	domain.RuleInclude:          "Include directive cannot be resolved",
+	domain.RuleAlias:            "Alias or redirect conflicts with another resource or points nowhere",
+	domain.RuleContentReadError: "File cannot be read",
+}
+
+// validationReport is the JSON representation of a validation run
+type validationReport struct {
+	Issues   []domain.Issue `json:"issues"`
+	Errors   int            `json:"errors"`
+	Warnings int            `json:"warnings"`
+}
+
+// ValidateContent checks the content directory described by settings and
+// returns every problem found. Problems with the content are reported as
+// issues; the error is reserved for failures to walk the content directory.
+func ValidateContent(settings *config.Settings) ([]domain.Issue, error) {
+	cp := content.NewContentProvider(settings.ContentDir)
+
+	var issues []domain.Issue
+	metadata, metadataErr := loadMetadata(cp)
+	if metadataErr != nil {
+		issues = append(issues, domain.NewError(domain.RuleMetadata, cp.GetPath(metadataFileName), "%v", metadataErr))
+	}
+
+	resourceDefinitions, resourceIssues, err := resources.DiscoverResourcesWithIssues(cp, settings.Scheme)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover resources: %w", err)
+	}
+	issues = append(issues, resourceIssues...)
+
+	_, templateIssues, err := resources.DiscoverResourceTemplatesWithIssues(cp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover resource templates: %w", err)
+	}
+	issues = append(issues, templateIssues...)
+	issues = append(issues, resources.FindUnresolvedLinks(resourceDefinitions, settings.Scheme, cp.ResourcesDir)...)
+	issues = append(issues, resources.FindUnresolvedIncludes(resourceDefinitions, cp.ResourcesDir)...)
+
+	promptDefinitions, promptIssues, err := prompts.DiscoverPromptsWithIssues(cp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover prompts: %w", err)
+	}
+	issues = append(issues, promptIssues...)
+
+	// Tools declared in metadata are only known once the metadata is valid
+	var declaredTools []tools.ToolDefinition
+	if metadataErr == nil {
+		if declaredTools, err = tools.FromMetadata(metadata.Tools); err != nil {
+			issues = append(issues, domain.NewError(domain.RuleMetadata, cp.GetPath(metadataFileName), "%v", err))
+		}
+	}
+	_, toolIssues, err := tools.DiscoverToolsWithIssues(cp, declaredTools, metadata.ExposedBuiltinToolNames())
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover tools: %w", err)
+	}
+	issues = append(issues, toolIssues...)
+
+	// Templated instructions can only be rendered once the metadata itself is
+	// valid
+	if metadataErr == nil && metadata.Server.InstructionsTemplate {
+		data := mcp.NewInstructionsData(
+			metadata,
+			resources.NewResourceProvider(resourceDefinitions),
+			prompts.NewPromptProvider(promptDefinitions, cp),
+		)
+		if _, err := mcp.RenderInstructions(metadata.Server.Instructions, data); err != nil {
+			issues = append(issues, domain.NewError(domain.RuleMetadata, cp.GetPath(metadataFileName), "%v", err))
+		}
+	}
+
+	return issues, nil
+}
+
+// RunValidate validates the content directory and writes a report to out.
+// It returns ErrValidationFailed if any error issue is found.
+func RunValidate(flags *pflag.FlagSet, out io.Writer) error {
+	settings, err := config.LoadSettingsWithFlags(flags)
+	if err != nil {
+		return fmt.Errorf("failed to load settings: %w", err)
+	}
+	if err := config.ValidateSettings(settings); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	output, _ := flags.GetString("output")
+	if output != OutputFormatText && output != OutputFormatJSON && output != OutputFormatSARIF {
+		return fmt.Errorf("output must be '%s', '%s' or '%s', got: %s", OutputFormatText, OutputFormatJSON, OutputFormatSARIF, output)
+	}
+
+	// Problems are reported in the output, discovery logs would only duplicate them
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError})))
+
+	issues, err := ValidateContent(settings)
+	if err != nil {
+		return err
+	}
+
+	report := validationReport{Issues: make([]domain.Issue, len(issues))}
+	for i, issue := range issues {
+		issue.File = relativePath(settings.ContentDir, issue.File)
+		report.Issues[i] = issue
+		if issue.Severity == domain.SeverityError {
+			report.Errors++
+		} else {
+			report.Warnings++
+		}
+	}
+
+	switch output {
+	case OutputFormatJSON:
+		err = writeJSON(out, report)
+	case OutputFormatSARIF:
+		err = writeJSON(out, newSARIFLog(report.Issues))
+	default:
+		err = writeValidationText(out, report)
+	}
+	if err != nil {
+		return err
+	}
+
+	if report.Errors > 0 {
+		return fmt.Errorf("%w: %d error(s), %d warning(s)", ErrValidationFailed, report.Errors, report.Warnings)
+	}
+	return nil
+}
+
+// relativePath returns path relative to base using forward slashes, or path
+// unchanged if it is not under base
+func relativePath(base, path string) string {
+	if path == "" {
+		return path
+	}
+	rel, err := filepath.Rel(base, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+func writeJSON(out io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+func writeValidationText(out io.Writer, report validationReport) error {
+	if len(report.Issues) == 0 {
+		_, err := fmt.Fprintln(out, "No problems found")
+		return err
+	}
+
+	for _, issue := range report.Issues {
+		if _, err := fmt.Fprintf(out, "%s: %s\n", issue.Severity, issue); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(out, "\n%d error(s), %d warning(s)\n", report.Errors, report.Warnings)
+	return err
+}
+
+// SARIF 2.1.0 types, limited to the properties used by the report
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// newSARIFLog converts issues into a SARIF log with a single run
+func newSARIFLog(issues []domain.Issue) sarifLog {
+	rules := []sarifRule{}
+	seen := make(map[string]bool)
+	results := make([]sarifResult, len(issues))
+	for i, issue := range issues {
+		if !seen[issue.Rule] {
+			seen[issue.Rule] = true
+			rules = append(rules, sarifRule{ID: issue.Rule, ShortDescription: sarifMessage{Text: ruleDescriptions[issue.Rule]}})
+		}
+
+		results[i] = sarifResult{
+			RuleID:  issue.Rule,
+			Level:   issue.Severity,
+			Message: sarifMessage{Text: issue.Message},
+		}
+		if issue.File != "" {
+			results[i].Locations = []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: issue.File}},
+			}}
+		}
+	}
+
+	return sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "acdc-mcp",
+				InformationURI: "https://github.com/sha1n/mcp-acdc-server",
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	}
+}
@@ -0,0 +1,186 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+	"github.com/spf13/pflag"
+)
+
+func writeInvalidContent(t *testing.T) string {
+	t.Helper()
+	contentDir := t.TempDir()
+	resourcesDir := filepath.Join(contentDir, "mcp-resources")
+	promptsDir := filepath.Join(contentDir, "mcp-prompts")
+	_ = os.MkdirAll(resourcesDir, 0755)
+	_ = os.MkdirAll(promptsDir, 0755)
+
+	_ = os.WriteFile(filepath.Join(contentDir, "mcp-metadata.yaml"), []byte("server:\n  name: test\n"), 0644)
+	_ = os.WriteFile(filepath.Join(resourcesDir, "a.md"), []byte("---\nname: A\ndescription: a\n---\nSee [missing](missing.md)"), 0644)
+	_ = os.WriteFile(filepath.Join(resourcesDir, "b.md"), []byte("---\nname: B\n---\nBody"), 0644)
+	_ = os.WriteFile(filepath.Join(promptsDir, "p.md"), []byte("---\nname: p\ndescription: d\n---\n{{.unclosed"), 0644)
+	return contentDir
+}
+
+func runValidateWithArgs(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	flags := pflag.NewFlagSet("validate", pflag.ContinueOnError)
+	RegisterValidateFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	var out bytes.Buffer
+	err := RunValidate(flags, &out)
+	return out.String(), err
+}
+
+func TestRunValidate_Valid(t *testing.T) {
+	contentDir := writeSearchTestContent(t)
+
+	out, err := runValidateWithArgs(t, "--content-dir", contentDir)
+	if err != nil {
+		t.Fatalf("RunValidate failed: %v", err)
+	}
+	if !strings.Contains(out, "No problems found") {
+		t.Errorf("Unexpected output: %s", out)
+	}
+}
+
+func TestRunValidate_Text(t *testing.T) {
+	contentDir := writeInvalidContent(t)
+
+	out, err := runValidateWithArgs(t, "--content-dir", contentDir)
+	if !errors.Is(err, ErrValidationFailed) {
+		t.Fatalf("Expected ErrValidationFailed, got %v", err)
+	}
+
+	for _, want := range []string{
+		"error: mcp-metadata.yaml: metadata validation failed: server version is required [metadata]",
+		"error: mcp-resources/a.md: link \"missing.md\" does not resolve to a resource [unresolved-link]",
+		"error: mcp-resources/b.md: missing required frontmatter field(s): description [missing-required-field]",
+		"mcp-prompts/p.md",
+		"4 error(s), 0 warning(s)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestRunValidate_JSON(t *testing.T) {
+	contentDir := writeInvalidContent(t)
+
+	out, err := runValidateWithArgs(t, "--content-dir", contentDir, "--output", "json")
+	if !errors.Is(err, ErrValidationFailed) {
+		t.Fatalf("Expected ErrValidationFailed, got %v", err)
+	}
+
+	var report validationReport
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("Invalid JSON output: %v\n%s", err, out)
+	}
+	if report.Errors != 4 || len(report.Issues) != 4 {
+		t.Errorf("Expected 4 errors, got %+v", report)
+	}
+	if report.Issues[0].Rule != domain.RuleMetadata || report.Issues[0].File != "mcp-metadata.yaml" {
+		t.Errorf("Unexpected first issue: %+v", report.Issues[0])
+	}
+}
+
+func TestRunValidate_SARIF(t *testing.T) {
+	contentDir := writeInvalidContent(t)
+
+	out, err := runValidateWithArgs(t, "--content-dir", contentDir, "-o", "sarif")
+	if !errors.Is(err, ErrValidationFailed) {
+		t.Fatalf("Expected ErrValidationFailed, got %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal([]byte(out), &log); err != nil {
+		t.Fatalf("Invalid SARIF output: %v\n%s", err, out)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("Unexpected SARIF log: %+v", log)
+	}
+
+	run := log.Runs[0]
+	if len(run.Results) != 4 || len(run.Tool.Driver.Rules) != 4 {
+		t.Errorf("Expected 4 results and rules, got %d and %d", len(run.Results), len(run.Tool.Driver.Rules))
+	}
+	for _, r := range run.Tool.Driver.Rules {
+		if r.ShortDescription.Text == "" {
+			t.Errorf("Rule %s has no description", r.ID)
+		}
+	}
+	result := run.Results[2]
+	if result.Level != "error" || result.RuleID != domain.RuleUnresolvedLink {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+	if len(result.Locations) != 1 || result.Locations[0].PhysicalLocation.ArtifactLocation.URI != "mcp-resources/a.md" {
+		t.Errorf("Unexpected result location: %+v", result.Locations)
+	}
+}
+
+func TestRunValidate_Errors(t *testing.T) {
+	contentDir := writeInvalidContent(t)
+
+	if _, err := runValidateWithArgs(t, "--content-dir", contentDir, "--output", "xml"); err == nil || errors.Is(err, ErrValidationFailed) {
+		t.Errorf("Expected output format error, got %v", err)
+	}
+	if _, err := runValidateWithArgs(t, "--content-dir", filepath.Join(contentDir, "missing")); err == nil || errors.Is(err, ErrValidationFailed) {
+		t.Errorf("Expected configuration error, got %v", err)
+	}
+}
+
+func TestRunValidate_InstructionsExecutionError(t *testing.T) {
+	contentDir := writeSearchTestContent(t)
+	metadata := "server:\n  name: test\n  version: 1.0.0\n  instructions: \"{{.Unknown}}\"\n  instructions_template: true\n"
+	_ = os.WriteFile(filepath.Join(contentDir, "mcp-metadata.yaml"), []byte(metadata), 0644)
+
+	out, err := runValidateWithArgs(t, "--content-dir", contentDir)
+	if !errors.Is(err, ErrValidationFailed) {
+		t.Fatalf("Expected ErrValidationFailed, got %v", err)
+	}
+	if !strings.Contains(out, "failed to execute instructions template") {
+		t.Errorf("Unexpected output: %s", out)
+	}
+}
+
+func TestRunValidate_PlainInstructionsNotRendered(t *testing.T) {
+	contentDir := writeSearchTestContent(t)
+	metadata := "server:\n  name: test\n  version: 1.0.0\n  instructions: \"Prompts use {{.Unknown}} syntax\"\n"
+	_ = os.WriteFile(filepath.Join(contentDir, "mcp-metadata.yaml"), []byte(metadata), 0644)
+
+	if out, err := runValidateWithArgs(t, "--content-dir", contentDir); err != nil {
+		t.Fatalf("Expected plain instructions to be valid, got %v: %s", err, out)
+	}
+}
+
+func TestRunValidate_CustomTools(t *testing.T) {
+	contentDir := writeSearchTestContent(t)
+	metadata := "server:\n  name: test\n  version: 1.0.0\n  instructions: i\ntools:\n  - name: checklist\n    description: d\n    kind: template\n    template: \"{{nope}}\"\n"
+	_ = os.WriteFile(filepath.Join(contentDir, "mcp-metadata.yaml"), []byte(metadata), 0644)
+	toolsDir := filepath.Join(contentDir, "mcp-tools")
+	_ = os.MkdirAll(toolsDir, 0755)
+	_ = os.WriteFile(filepath.Join(toolsDir, "lookup.md"), []byte("---\nname: lookup\ndescription: d\nkind: fetch\n---\n"), 0644)
+
+	out, err := runValidateWithArgs(t, "--content-dir", contentDir)
+	if !errors.Is(err, ErrValidationFailed) {
+		t.Fatalf("Expected ErrValidationFailed, got %v", err)
+	}
+	for _, want := range []string{
+		`custom tool "checklist" has an invalid template`,
+		`mcp-tools/lookup.md: custom tool "lookup" has unsupported kind "fetch"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in output: %s", want, out)
+		}
+	}
+}
@@ -15,16 +15,26 @@ func LogWithLogger(s *Settings, logger *slog.Logger) {
 	ctx := context.Background()
 	logger.InfoContext(ctx, "Config: content_dir", "value", s.ContentDir)
 	logger.InfoContext(ctx, "Config: transport", "value", s.Transport)
-	if s.Transport == "sse" {
+	logger.InfoContext(ctx, "Config: strict", "value", s.Strict)
+	logger.InfoContext(ctx, "Config: prompt_tools", "value", s.PromptTools)
+	logger.InfoContext(ctx, "Config: read_many_max_kb", "value", s.ReadManyMaxKB)
+	if s.Transport != TransportStdio {
 		logger.InfoContext(ctx, "Config: host", "value", s.Host)
 		logger.InfoContext(ctx, "Config: port", "value", s.Port)
 	}
+	if s.Transport == TransportStreamableHTTP || s.Transport == TransportHTTP {
+		logger.InfoContext(ctx, "Config: session_timeout", "value", s.SessionTimeout)
+	}
 
 	logger.InfoContext(ctx, "Config: search.max_results", "value", s.Search.MaxResults)
 	logger.InfoContext(ctx, "Config: search.in_memory", "value", s.Search.InMemory)
 	logger.InfoContext(ctx, "Config: search.keywords_boost", "value", s.Search.KeywordsBoost)
 	logger.InfoContext(ctx, "Config: search.name_boost", "value", s.Search.NameBoost)
 	logger.InfoContext(ctx, "Config: search.content_boost", "value", s.Search.ContentBoost)
+	logger.InfoContext(ctx, "Config: search.explain", "value", s.Search.Explain)
+	logger.InfoContext(ctx, "Config: search.priority_multiplier", "value", s.Search.PriorityMultiplier)
+	logger.InfoContext(ctx, "Config: search.recency_half_life_days", "value", s.Search.RecencyHalfLifeDays)
+	logger.InfoContext(ctx, "Config: search.deprecation_penalty", "value", s.Search.DeprecationPenalty)
 
 	logger.InfoContext(ctx, "Config: auth.type", "value", s.Auth.Type)
 	switch s.Auth.Type {
@@ -44,6 +54,10 @@ func SearchSettingsLogValue(s SearchSettings) slog.Value {
 		slog.Float64("keywords_boost", s.KeywordsBoost),
 		slog.Float64("name_boost", s.NameBoost),
 		slog.Float64("content_boost", s.ContentBoost),
+		slog.Bool("explain", s.Explain),
+		slog.Float64("priority_multiplier", s.PriorityMultiplier),
+		slog.Float64("recency_half_life_days", s.RecencyHalfLifeDays),
+		slog.Float64("deprecation_penalty", s.DeprecationPenalty),
 	)
 }
 
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -21,8 +22,22 @@ type SearchSettings struct {
 	KeywordsBoost float64 `mapstructure:"keywords_boost"`
 	NameBoost     float64 `mapstructure:"name_boost"`
 	ContentBoost  float64 `mapstructure:"content_boost"`
+	Explain       bool    `mapstructure:"explain"`
+
+	// Score modifiers driven by resource frontmatter (0 disables each)
+	PriorityMultiplier  float64 `mapstructure:"priority_multiplier"`
+	RecencyHalfLifeDays float64 `mapstructure:"recency_half_life_days"`
+	DeprecationPenalty  float64 `mapstructure:"deprecation_penalty"`
 }
 
+// Transport type constants
+const (
+	TransportStdio          = "stdio"
+	TransportSSE            = "sse"
+	TransportStreamableHTTP = "streamable-http"
+	TransportHTTP           = "http" // Streamable HTTP and SSE on the same port
+)
+
 // Auth type constants
 const (
 	AuthTypeNone   = "none"
@@ -45,14 +60,19 @@ type BasicAuthSettings struct {
 
 // Settings application settings
 type Settings struct {
-	ContentDir string         `mapstructure:"content_dir"`
-	Transport  string         `mapstructure:"transport"`
-	Host       string         `mapstructure:"host"`
-	Port       int            `mapstructure:"port"`
-	Scheme     string         `mapstructure:"uri_scheme"`
-	CrossRef   bool           `mapstructure:"cross_ref"`
-	Search     SearchSettings `mapstructure:"search"`
-	Auth       AuthSettings   `mapstructure:"auth"`
+	ContentDir         string         `mapstructure:"content_dir"`
+	Transport          string         `mapstructure:"transport"`
+	Host               string         `mapstructure:"host"`
+	Port               int            `mapstructure:"port"`
+	SessionTimeout     time.Duration  `mapstructure:"session_timeout"`
+	Scheme             string         `mapstructure:"uri_scheme"`
+	CrossRef           bool           `mapstructure:"cross_ref"`
+	CrossRefMarkBroken bool           `mapstructure:"cross_ref_mark_broken"`
+	Strict             bool           `mapstructure:"strict"`
+	PromptTools        bool           `mapstructure:"prompt_tools"`
+	ReadManyMaxKB      int            `mapstructure:"read_many_max_kb"`
+	Search             SearchSettings `mapstructure:"search"`
+	Auth               AuthSettings   `mapstructure:"auth"`
 }
 
 // LoadSettings loads settings from environment variables and optional .env file
@@ -74,12 +94,21 @@ func LoadSettingsWithFlags(flags *pflag.FlagSet) (*Settings, error) {
 	v.SetDefault("transport", "stdio")
 	v.SetDefault("host", "0.0.0.0")
 	v.SetDefault("port", 8080)
+	v.SetDefault("session_timeout", 30*time.Minute)
 	v.SetDefault("uri_scheme", "acdc")
 	v.SetDefault("search.max_results", 10)
 	v.SetDefault("search.keywords_boost", 3.0)
 	v.SetDefault("search.name_boost", 2.0)
 	v.SetDefault("search.content_boost", 1.0)
+	v.SetDefault("search.explain", false)
+	v.SetDefault("search.priority_multiplier", 0.0)
+	v.SetDefault("search.recency_half_life_days", 0.0)
+	v.SetDefault("search.deprecation_penalty", 0.0)
 	v.SetDefault("cross_ref", false)
+	v.SetDefault("cross_ref_mark_broken", false)
+	v.SetDefault("strict", false)
+	v.SetDefault("prompt_tools", false)
+	v.SetDefault("read_many_max_kb", 256)
 	v.SetDefault("auth.type", AuthTypeNone)
 
 	// Environment variables
@@ -94,9 +123,18 @@ func LoadSettingsWithFlags(flags *pflag.FlagSet) (*Settings, error) {
 	_ = v.BindEnv("search.keywords_boost", "ACDC_MCP_SEARCH_KEYWORDS_BOOST")
 	_ = v.BindEnv("search.name_boost", "ACDC_MCP_SEARCH_NAME_BOOST")
 	_ = v.BindEnv("search.content_boost", "ACDC_MCP_SEARCH_CONTENT_BOOST")
+	_ = v.BindEnv("search.explain", "ACDC_MCP_SEARCH_EXPLAIN")
+	_ = v.BindEnv("search.priority_multiplier", "ACDC_MCP_SEARCH_PRIORITY_MULTIPLIER")
+	_ = v.BindEnv("search.recency_half_life_days", "ACDC_MCP_SEARCH_RECENCY_HALF_LIFE_DAYS")
+	_ = v.BindEnv("search.deprecation_penalty", "ACDC_MCP_SEARCH_DEPRECATION_PENALTY")
 
+	_ = v.BindEnv("session_timeout", "ACDC_MCP_SESSION_TIMEOUT")
 	_ = v.BindEnv("uri_scheme", "ACDC_MCP_URI_SCHEME")
 	_ = v.BindEnv("cross_ref", "ACDC_MCP_CROSS_REF")
+	_ = v.BindEnv("cross_ref_mark_broken", "ACDC_MCP_CROSS_REF_MARK_BROKEN")
+	_ = v.BindEnv("strict", "ACDC_MCP_STRICT")
+	_ = v.BindEnv("prompt_tools", "ACDC_MCP_PROMPT_TOOLS")
+	_ = v.BindEnv("read_many_max_kb", "ACDC_MCP_READ_MANY_MAX_KB")
 
 	_ = v.BindEnv("auth.type", "ACDC_MCP_AUTH_TYPE")
 	_ = v.BindEnv("auth.basic.username", "ACDC_MCP_AUTH_BASIC_USERNAME")
@@ -109,12 +147,21 @@ func LoadSettingsWithFlags(flags *pflag.FlagSet) (*Settings, error) {
 		_ = v.BindPFlag("transport", flags.Lookup("transport"))
 		_ = v.BindPFlag("host", flags.Lookup("host"))
 		_ = v.BindPFlag("port", flags.Lookup("port"))
+		_ = v.BindPFlag("session_timeout", flags.Lookup("session-timeout"))
 		_ = v.BindPFlag("uri_scheme", flags.Lookup("uri-scheme"))
 		_ = v.BindPFlag("cross_ref", flags.Lookup("cross-ref"))
+		_ = v.BindPFlag("cross_ref_mark_broken", flags.Lookup("cross-ref-mark-broken"))
+		_ = v.BindPFlag("strict", flags.Lookup("strict"))
+		_ = v.BindPFlag("prompt_tools", flags.Lookup("prompt-tools"))
+		_ = v.BindPFlag("read_many_max_kb", flags.Lookup("read-many-max-kb"))
 		_ = v.BindPFlag("search.max_results", flags.Lookup("search-max-results"))
 		_ = v.BindPFlag("search.keywords_boost", flags.Lookup("search-keywords-boost"))
 		_ = v.BindPFlag("search.name_boost", flags.Lookup("search-name-boost"))
 		_ = v.BindPFlag("search.content_boost", flags.Lookup("search-content-boost"))
+		_ = v.BindPFlag("search.explain", flags.Lookup("search-explain"))
+		_ = v.BindPFlag("search.priority_multiplier", flags.Lookup("search-priority-multiplier"))
+		_ = v.BindPFlag("search.recency_half_life_days", flags.Lookup("search-recency-half-life-days"))
+		_ = v.BindPFlag("search.deprecation_penalty", flags.Lookup("search-deprecation-penalty"))
 		_ = v.BindPFlag("auth.type", flags.Lookup("auth-type"))
 		_ = v.BindPFlag("auth.basic.username", flags.Lookup("auth-basic-username"))
 		_ = v.BindPFlag("auth.basic.password", flags.Lookup("auth-basic-password"))
@@ -156,10 +203,18 @@ func LoadSettingsWithFlags(flags *pflag.FlagSet) (*Settings, error) {
 func ValidateSettings(s *Settings) error {
 	// Validate transport type
 	switch s.Transport {
-	case "stdio", "sse":
+	case TransportStdio, TransportSSE, TransportStreamableHTTP, TransportHTTP:
 		// valid
 	default:
-		return errors.New("transport must be 'stdio' or 'sse', got: " + s.Transport)
+		return errors.New("transport must be 'stdio', 'sse', 'streamable-http' or 'http', got: " + s.Transport)
+	}
+
+	if s.SessionTimeout < 0 {
+		return errors.New("session-timeout must not be negative")
+	}
+
+	if s.ReadManyMaxKB < 0 {
+		return errors.New("read-many-max-kb must not be negative")
 	}
 
 	// Validate URI scheme (RFC 3986: ALPHA *( ALPHA / DIGIT / "+" / "-" / "." ))
@@ -167,6 +222,17 @@ func ValidateSettings(s *Settings) error {
 		return errors.New("scheme must match RFC 3986 (start with a letter, contain only letters, digits, +, -, .), got: " + s.Scheme)
 	}
 
+	// Validate search score modifiers
+	if s.Search.PriorityMultiplier < 0 {
+		return errors.New("search-priority-multiplier must not be negative")
+	}
+	if s.Search.RecencyHalfLifeDays < 0 {
+		return errors.New("search-recency-half-life-days must not be negative")
+	}
+	if s.Search.DeprecationPenalty < 0 || s.Search.DeprecationPenalty > 1 {
+		return errors.New("search-deprecation-penalty must be between 0 and 1")
+	}
+
 	hasBasicCreds := s.Auth.Basic.Username != "" || s.Auth.Basic.Password != ""
 	hasAPIKeys := len(s.Auth.APIKeys) > 0
 
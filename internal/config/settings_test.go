@@ -4,6 +4,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/spf13/pflag"
 )
@@ -498,13 +499,85 @@ func TestValidateSettings_ValidTransportSSE(t *testing.T) {
 	}
 }
 
+func TestValidateSettings_ValidTransportStreamableHTTP(t *testing.T) {
+	for _, transport := range []string{TransportStreamableHTTP, TransportHTTP} {
+		s := &Settings{Transport: transport, Scheme: "acdc", Auth: AuthSettings{Type: AuthTypeNone}}
+		if err := ValidateSettings(s); err != nil {
+			t.Errorf("Expected no error for valid %s transport, got: %v", transport, err)
+		}
+	}
+}
+
+func TestValidateSettings_NegativeSessionTimeout(t *testing.T) {
+	s := &Settings{Transport: TransportStreamableHTTP, Scheme: "acdc", SessionTimeout: -time.Second, Auth: AuthSettings{Type: AuthTypeNone}}
+	if err := ValidateSettings(s); err == nil {
+		t.Error("Expected error for negative session timeout")
+	}
+}
+
+func TestValidateSettings_NegativeReadManyMaxKB(t *testing.T) {
+	s := &Settings{Transport: TransportStdio, Scheme: "acdc", ReadManyMaxKB: -1, Auth: AuthSettings{Type: AuthTypeNone}}
+	if err := ValidateSettings(s); err == nil {
+		t.Error("Expected error for negative read_many_max_kb")
+	}
+}
+
+func TestLoadSettings_ReadManyMaxKB(t *testing.T) {
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+	if settings.ReadManyMaxKB != 256 {
+		t.Errorf("Expected default read_many_max_kb 256, got %d", settings.ReadManyMaxKB)
+	}
+
+	t.Setenv("ACDC_MCP_READ_MANY_MAX_KB", "64")
+	settings, err = LoadSettings()
+	if err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+	if settings.ReadManyMaxKB != 64 {
+		t.Errorf("Expected read_many_max_kb 64, got %d", settings.ReadManyMaxKB)
+	}
+}
+
+func TestLoadSettings_SessionTimeout(t *testing.T) {
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+	if settings.SessionTimeout != 30*time.Minute {
+		t.Errorf("Expected default session timeout 30m, got %v", settings.SessionTimeout)
+	}
+
+	t.Setenv("ACDC_MCP_SESSION_TIMEOUT", "5m")
+	settings, err = LoadSettings()
+	if err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+	if settings.SessionTimeout != 5*time.Minute {
+		t.Errorf("Expected session timeout 5m from env, got %v", settings.SessionTimeout)
+	}
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.Duration("session-timeout", 0, "")
+	_ = flags.Set("session-timeout", "0s")
+	settings, err = LoadSettingsWithFlags(flags)
+	if err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+	if settings.SessionTimeout != 0 {
+		t.Errorf("Expected session timeout disabled from CLI flag, got %v", settings.SessionTimeout)
+	}
+}
+
 func TestValidateSettings_InvalidTransport(t *testing.T) {
 	tests := []struct {
 		name      string
 		transport string
 	}{
 		{"empty transport", ""},
-		{"http transport", "http"},
+		{"grpc transport", "grpc"},
 		{"websocket transport", "websocket"},
 		{"unknown transport", "foobar"},
 	}
@@ -559,6 +632,91 @@ func TestLoadSettingsWithFlags_CrossRefCLIOverridesEnv(t *testing.T) {
 	}
 }
 
+func TestLoadSettings_CrossRefMarkBrokenEnvVar(t *testing.T) {
+	t.Setenv("ACDC_MCP_CROSS_REF_MARK_BROKEN", "true")
+
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+
+	if !settings.CrossRefMarkBroken {
+		t.Errorf("Expected cross_ref_mark_broken true, got %v", settings.CrossRefMarkBroken)
+	}
+}
+
+func TestLoadSettings_StrictEnvVar(t *testing.T) {
+	t.Setenv("ACDC_MCP_STRICT", "true")
+
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+
+	if !settings.Strict {
+		t.Errorf("Expected strict true, got %v", settings.Strict)
+	}
+}
+
+func TestLoadSettings_PromptToolsEnvVar(t *testing.T) {
+	t.Setenv("ACDC_MCP_PROMPT_TOOLS", "true")
+
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+
+	if !settings.PromptTools {
+		t.Errorf("Expected prompt_tools true, got %v", settings.PromptTools)
+	}
+}
+
+// --- Search Explain Tests ---
+
+func TestLoadSettings_SearchExplainEnvVar(t *testing.T) {
+	t.Setenv("ACDC_MCP_SEARCH_EXPLAIN", "true")
+
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+
+	if !settings.Search.Explain {
+		t.Errorf("Expected search.explain true, got %v", settings.Search.Explain)
+	}
+}
+
+func TestValidateSettings_ScoreModifiers(t *testing.T) {
+	tests := []struct {
+		name    string
+		search  SearchSettings
+		wantErr string
+	}{
+		{"Disabled", SearchSettings{}, ""},
+		{"Valid", SearchSettings{PriorityMultiplier: 0.5, RecencyHalfLifeDays: 180, DeprecationPenalty: 1}, ""},
+		{"Negative priority multiplier", SearchSettings{PriorityMultiplier: -1}, "search-priority-multiplier"},
+		{"Negative half-life", SearchSettings{RecencyHalfLifeDays: -1}, "search-recency-half-life-days"},
+		{"Penalty above one", SearchSettings{DeprecationPenalty: 1.5}, "search-deprecation-penalty"},
+		{"Negative penalty", SearchSettings{DeprecationPenalty: -0.1}, "search-deprecation-penalty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Settings{Transport: "stdio", Scheme: "acdc", Search: tt.search, Auth: AuthSettings{Type: AuthTypeNone}}
+			err := ValidateSettings(s)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
 // --- Scheme Tests ---
 
 func TestLoadSettings_SchemeEnvVar(t *testing.T) {
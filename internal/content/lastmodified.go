@@ -0,0 +1,90 @@
+package content
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// commitMarker prefixes the commit date lines of the git log output parsed by
+// loadCommitTimes, so they cannot be confused with file names. git writes it
+// for the %x00 placeholder of the log format.
+const commitMarker = "\x00"
+
+// LastModifiedResolver resolves when content files were last modified. Files
+// tracked by Git use the date of the last commit that changed them, since file
+// modification times only reflect when a clone was checked out. Other files
+// use their modification time.
+type LastModifiedResolver struct {
+	dir     string
+	commits map[string]time.Time // Slash separated paths relative to dir
+}
+
+// NewLastModifiedResolver creates a resolver for the files under dir. The Git
+// history is loaded once; if dir is not in a Git work tree or git is not
+// installed, modification times are used for every file.
+func NewLastModifiedResolver(dir string) *LastModifiedResolver {
+	commits, err := loadCommitTimes(dir)
+	if err != nil {
+		slog.Debug("Git history not available, using file modification times", "path", dir, "error", err)
+	}
+	return &LastModifiedResolver{dir: dir, commits: commits}
+}
+
+// LastModified returns the last modification time of the file at path
+func (r *LastModifiedResolver) LastModified(path string) (time.Time, error) {
+	if rel, err := filepath.Rel(r.dir, path); err == nil {
+		if t, ok := r.commits[filepath.ToSlash(rel)]; ok {
+			return t, nil
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
+
+// loadCommitTimes maps every file under dir that is tracked by Git to the
+// commit date of the last commit that changed it
+func loadCommitTimes(dir string) (map[string]time.Time, error) {
+	cmd := exec.Command("git", "-C", dir, "-c", "core.quotepath=off", "log", "--format=%x00%cI", "--name-only", "--relative", "--", ".")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+		return nil, err
+	}
+
+	commits := make(map[string]time.Time)
+	var current time.Time
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if date, ok := strings.CutPrefix(line, commitMarker); ok {
+			current, err = time.Parse(time.RFC3339, date)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if line == "" {
+			continue
+		}
+		// The log lists the newest commits first
+		if _, seen := commits[line]; !seen {
+			commits[line] = current
+		}
+	}
+	return commits, scanner.Err()
+}
@@ -0,0 +1,78 @@
+package content
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLastModifiedResolver_ModTime(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "doc.md")
+	require.NoError(t, os.WriteFile(path, []byte("x"), 0644))
+	modTime := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+
+	r := NewLastModifiedResolver(dir)
+	got, err := r.LastModified(path)
+	require.NoError(t, err)
+	assert.True(t, modTime.Equal(got), "expected %v, got %v", modTime, got)
+
+	_, err = r.LastModified(filepath.Join(dir, "missing.md"))
+	assert.Error(t, err)
+}
+
+func TestLastModifiedResolver_GitHistory(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	repo := t.TempDir()
+	dir := filepath.Join(repo, "content")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "guides"), 0755))
+
+	git := func(date string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", repo}, args...)...)
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+			"GIT_AUTHOR_DATE="+date, "GIT_COMMITTER_DATE="+date,
+		)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	write := func(rel, text string) string {
+		path := filepath.Join(dir, rel)
+		require.NoError(t, os.WriteFile(path, []byte(text), 0644))
+		return path
+	}
+
+	git("", "init", "-q")
+	first := write("first.md", "v1")
+	second := write("guides/second.md", "v1")
+	git("2024-01-01T10:00:00Z", "add", "-A")
+	git("2024-01-01T10:00:00Z", "commit", "-q", "-m", "initial")
+	write("guides/second.md", "v2")
+	git("2024-02-01T10:00:00Z", "commit", "-q", "-am", "update")
+	untracked := write("untracked.md", "new")
+
+	r := NewLastModifiedResolver(dir)
+
+	got, err := r.LastModified(first)
+	require.NoError(t, err)
+	assert.True(t, got.Equal(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)), "got %v", got)
+
+	got, err = r.LastModified(second)
+	require.NoError(t, err)
+	assert.True(t, got.Equal(time.Date(2024, 2, 1, 10, 0, 0, 0, time.UTC)), "got %v", got)
+
+	got, err = r.LastModified(untracked)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), got, time.Hour, "untracked files use their modification time")
+}
@@ -0,0 +1,107 @@
+package content
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ExtractSection returns the markdown section under the first heading whose
+// text matches heading, ignoring case and surrounding whitespace. The section
+// includes the heading line and ends before the next heading of the same or a
+// higher level. Headings inside fenced code blocks are ignored.
+func ExtractSection(markdown, heading string) (string, bool) {
+	lines := strings.Split(markdown, "\n")
+	target := strings.TrimSpace(heading)
+
+	start, level := -1, 0
+	inFence := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		l, text := parseHeading(trimmed)
+		if l == 0 {
+			continue
+		}
+		if start < 0 {
+			if strings.EqualFold(text, target) {
+				start, level = i, l
+			}
+			continue
+		}
+		if l <= level {
+			return strings.TrimSpace(strings.Join(lines[start:i], "\n")), true
+		}
+	}
+
+	if start < 0 {
+		return "", false
+	}
+	return strings.TrimSpace(strings.Join(lines[start:], "\n")), true
+}
+
+// parseHeading returns the level and text of an ATX heading, or level 0 if
+// line is not a heading
+func parseHeading(line string) (int, string) {
+	level := 0
+	for level < len(line) && line[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || (level < len(line) && line[level] != ' ' && line[level] != '\t') {
+		return 0, ""
+	}
+	text := strings.TrimSpace(line[level:])
+	text = strings.TrimSpace(strings.TrimRight(text, "#"))
+	return level, text
+}
+
+// HeadingAnchors returns the anchors that markdown renderers such as GitHub
+// generate for the headings of markdown: the heading text in lower case with
+// punctuation removed and spaces replaced by hyphens. Repeated anchors get a
+// numeric suffix (-1, -2, ...). Headings inside fenced code blocks are ignored.
+func HeadingAnchors(markdown string) map[string]bool {
+	anchors := make(map[string]bool)
+	counts := make(map[string]int)
+	inFence := false
+	for _, line := range strings.Split(markdown, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if level, text := parseHeading(trimmed); level > 0 {
+			anchor := headingAnchor(text)
+			if n := counts[anchor]; n > 0 {
+				anchors[fmt.Sprintf("%s-%d", anchor, n)] = true
+			} else {
+				anchors[anchor] = true
+			}
+			counts[anchor]++
+		}
+	}
+	return anchors
+}
+
+// headingAnchor returns the anchor of a heading
+func headingAnchor(text string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '-', r == '_':
+			sb.WriteRune(r)
+		case r == ' ':
+			sb.WriteRune('-')
+		}
+	}
+	return sb.String()
+}
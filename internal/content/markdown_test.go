@@ -0,0 +1,72 @@
+package content
+
+import "testing"
+
+func TestExtractSection(t *testing.T) {
+	markdown := `# Guide
+
+Intro
+
+## Setup
+
+Install it.
+
+### Details
+
+` + "```bash\n# not a heading\n```" + `
+
+## Usage ##
+
+Run it.
+`
+
+	tests := []struct {
+		name    string
+		heading string
+		want    string
+		found   bool
+	}{
+		{name: "Nested", heading: "Setup", want: "## Setup\n\nInstall it.\n\n### Details\n\n```bash\n# not a heading\n```", found: true},
+		{name: "CaseInsensitive", heading: " details ", want: "### Details\n\n```bash\n# not a heading\n```", found: true},
+		{name: "ClosingHashes", heading: "Usage", want: "## Usage ##\n\nRun it.", found: true},
+		{name: "HeadingInFence", heading: "not a heading", found: false},
+		{name: "Missing", heading: "Nope", found: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := ExtractSection(markdown, tt.heading)
+			if found != tt.found {
+				t.Fatalf("found = %v, want %v", found, tt.found)
+			}
+			if got != tt.want {
+				t.Errorf("ExtractSection() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseHeading(t *testing.T) {
+	if l, text := parseHeading("## Title"); l != 2 || text != "Title" {
+		t.Errorf("parseHeading = %d %q", l, text)
+	}
+	if l, _ := parseHeading("#hashtag"); l != 0 {
+		t.Errorf("Expected #hashtag not to be a heading, got level %d", l)
+	}
+	if l, _ := parseHeading("####### seven"); l != 0 {
+		t.Errorf("Expected seven hashes not to be a heading, got level %d", l)
+	}
+}
+
+func TestHeadingAnchors(t *testing.T) {
+	markdown := "# Getting Started\n\n## What's new? ##\n\n## FAQ\n\n## FAQ\n\n```\n## In fence\n```\n\n### Step 1: Install `acdc`\n"
+
+	got := HeadingAnchors(markdown)
+	for _, anchor := range []string{"getting-started", "whats-new", "faq", "faq-1", "step-1-install-acdc"} {
+		if !got[anchor] {
+			t.Errorf("missing anchor %q in %v", anchor, got)
+		}
+	}
+	if len(got) != 5 {
+		t.Errorf("got %d anchors, want 5: %v", len(got), got)
+	}
+}
@@ -20,6 +20,7 @@ type ContentProvider struct {
 	ContentDir   string
 	ResourcesDir string
 	PromptsDir   string
+	ToolsDir     string
 }
 
 // NewContentProvider creates a new ContentProvider
@@ -28,6 +29,7 @@ func NewContentProvider(contentDir string) *ContentProvider {
 		ContentDir:   contentDir,
 		ResourcesDir: filepath.Join(contentDir, "mcp-resources"),
 		PromptsDir:   filepath.Join(contentDir, "mcp-prompts"),
+		ToolsDir:     filepath.Join(contentDir, "mcp-tools"),
 	}
 }
 
@@ -136,3 +138,21 @@ func (p *ContentProvider) LoadMarkdownWithFrontmatter(filePath string) (*Markdow
 		Content:  markdownContent,
 	}, nil
 }
+
+// MissingFields returns the names of the given frontmatter fields that are
+// absent, empty or not strings
+func (m *MarkdownWithFrontmatter) MissingFields(fields ...string) []string {
+	return MissingFields(m.Metadata, fields...)
+}
+
+// MissingFields returns the names of the given metadata fields that are
+// absent, empty or not strings
+func MissingFields(metadata map[string]interface{}, fields ...string) []string {
+	var missing []string
+	for _, f := range fields {
+		if s, _ := metadata[f].(string); s == "" {
+			missing = append(missing, f)
+		}
+	}
+	return missing
+}
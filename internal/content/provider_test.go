@@ -3,6 +3,7 @@ package content
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -173,3 +174,22 @@ func TestContentProvider_GetPath(t *testing.T) {
 		t.Errorf("Expected '%s', got '%s'", expected, path)
 	}
 }
+
+func TestMarkdownWithFrontmatter_MissingFields(t *testing.T) {
+	md := &MarkdownWithFrontmatter{
+		Metadata: map[string]interface{}{
+			"name":        "N",
+			"description": "",
+			"keywords":    []interface{}{"k"},
+		},
+	}
+
+	missing := md.MissingFields("name", "description", "keywords", "title")
+	want := []string{"description", "keywords", "title"}
+	if strings.Join(missing, ",") != strings.Join(want, ",") {
+		t.Errorf("MissingFields() = %v, want %v", missing, want)
+	}
+	if got := md.MissingFields("name"); len(got) != 0 {
+		t.Errorf("MissingFields(name) = %v, want none", got)
+	}
+}
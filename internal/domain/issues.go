@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Issue severity constants
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Issue rule identifiers
+const (
+	RuleMetadata         = "metadata"
+	RuleFrontmatter      = "invalid-frontmatter"
+	RuleRequiredField    = "missing-required-field"
+	RuleDuplicateURI     = "duplicate-uri"
+	RuleDuplicatePrompt  = "duplicate-prompt-name"
+	RuleDuplicateTool    = "duplicate-tool-name"
+	RuleTemplate         = "invalid-template"
+	RuleUnresolvedLink   = "unresolved-link"
+	RuleMissingAnchor    = "missing-anchor"
+	RuleAttachment       = "invalid-attachment"
+	RuleInclude          = "invalid-include"
+	RuleAlias            = "invalid-alias"
+	RuleContentReadError = "read-error"
+)
+
+// Issue is a problem found in the content directory
+type Issue struct {
+	Severity string `json:"severity"`
+	Rule     string `json:"rule"`
+	File     string `json:"file,omitempty"`
+	Message  string `json:"message"`
+}
+
+// NewError creates an issue with error severity
+func NewError(rule, file, format string, args ...interface{}) Issue {
+	return Issue{Severity: SeverityError, Rule: rule, File: file, Message: fmt.Sprintf(format, args...)}
+}
+
+// NewWarning creates an issue with warning severity
+func NewWarning(rule, file, format string, args ...interface{}) Issue {
+	return Issue{Severity: SeverityWarning, Rule: rule, File: file, Message: fmt.Sprintf(format, args...)}
+}
+
+// String formats the issue as "file: message [rule]"
+func (i Issue) String() string {
+	if i.File == "" {
+		return fmt.Sprintf("%s [%s]", i.Message, i.Rule)
+	}
+	return fmt.Sprintf("%s: %s [%s]", i.File, i.Message, i.Rule)
+}
+
+// IssuesError aggregates content issues into a single error
+type IssuesError struct {
+	Issues []Issue
+}
+
+// Error lists every issue on its own line
+func (e *IssuesError) Error() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("found %d content problem(s):", len(e.Issues)))
+	for _, issue := range e.Issues {
+		sb.WriteString("\n  - ")
+		sb.WriteString(issue.String())
+	}
+	return sb.String()
+}
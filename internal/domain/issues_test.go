@@ -0,0 +1,34 @@
+package domain
+
+import "testing"
+
+func TestIssue_String(t *testing.T) {
+	tests := []struct {
+		issue Issue
+		want  string
+	}{
+		{NewError(RuleTemplate, "mcp-prompts/p.md", "bad %s", "action"), "mcp-prompts/p.md: bad action [invalid-template]"},
+		{NewWarning(RuleMetadata, "", "no file"), "no file [metadata]"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.issue.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+	if tests[1].issue.Severity != SeverityWarning {
+		t.Errorf("Expected warning severity, got %s", tests[1].issue.Severity)
+	}
+}
+
+func TestIssuesError(t *testing.T) {
+	err := &IssuesError{Issues: []Issue{
+		NewError(RuleFrontmatter, "a.md", "bad yaml"),
+		NewError(RuleDuplicatePrompt, "b.md", "duplicate"),
+	}}
+
+	want := "found 2 content problem(s):\n  - a.md: bad yaml [invalid-frontmatter]\n  - b.md: duplicate [duplicate-prompt-name]"
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
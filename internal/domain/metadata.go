@@ -2,6 +2,12 @@ package domain
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/yosida95/uritemplate/v3"
 )
 
 // ServerMetadata represents the server section of mcp-metadata.yaml
@@ -9,18 +15,106 @@ type ServerMetadata struct {
 	Name         string `yaml:"name"`
 	Version      string `yaml:"version"`
 	Instructions string `yaml:"instructions"`
+	// InstructionsTemplate renders Instructions as a text template against
+	// the discovered content; otherwise they are sent as-is
+	InstructionsTemplate bool `yaml:"instructions_template"`
 }
 
-// ToolMetadata represents a tool definition in mcp-metadata.yaml
+// Custom tool handler kinds
+const (
+	ToolKindTemplate = "template" // Renders a template with the tool arguments
+	ToolKindSearch   = "search"   // Searches with preset filters
+	ToolKindRead     = "read"     // Reads the resource at a templated URI
+)
+
+// toolNameRegexp matches the tool names accepted by MCP clients
+var toolNameRegexp = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,128}$`)
+
+// ToolAnnotations represents MCP tool annotations in mcp-metadata.yaml.
+// Unset hints keep the server defaults.
+type ToolAnnotations struct {
+	Title          string `yaml:"title"`
+	ReadOnlyHint   *bool  `yaml:"readOnlyHint"`
+	IdempotentHint *bool  `yaml:"idempotentHint"`
+}
+
+// ToolMetadata represents a tool definition in mcp-metadata.yaml. Entries
+// without a kind configure the built-in tool of the same name; entries with a
+// kind define custom tools.
 type ToolMetadata struct {
+	Name        string           `yaml:"name"`
+	Description string           `yaml:"description"`
+	Enabled     *bool            `yaml:"enabled"`     // Defaults to true
+	Rename      string           `yaml:"rename"`      // Name a built-in tool is exposed as
+	Annotations *ToolAnnotations `yaml:"annotations"` // Optional MCP tool annotations
+
+	// Custom tool fields
+	Kind        string                 `yaml:"kind"`
+	InputSchema map[string]interface{} `yaml:"input_schema"`
+	Template    string                 `yaml:"template"`   // Response template of template tools
+	Query       string                 `yaml:"query"`      // Query template of search tools, defaults to the query argument
+	URI         string                 `yaml:"uri"`        // URI template of read tools
+	URIPrefix   string                 `yaml:"uri_prefix"` // Search scope of search tools
+	Limit       int                    `yaml:"limit"`      // Maximum results of search tools
+}
+
+// IsCustom reports whether the entry defines a custom tool
+func (t ToolMetadata) IsCustom() bool {
+	return t.Kind != ""
+}
+
+// IsEnabled reports whether the tool is registered
+func (t ToolMetadata) IsEnabled() bool {
+	return t.Enabled == nil || *t.Enabled
+}
+
+// ExposedName returns the name the tool is registered with
+func (t ToolMetadata) ExposedName() string {
+	if t.Rename != "" {
+		return t.Rename
+	}
+	return t.Name
+}
+
+// ValidateCustomTool checks the fields of a custom tool definition
+func (t ToolMetadata) ValidateCustomTool() error {
+	if _, builtin := DefaultToolMetadata[t.Name]; builtin {
+		return fmt.Errorf("custom tool %q conflicts with a built-in tool", t.Name)
+	}
+	if t.Rename != "" {
+		return fmt.Errorf("custom tool %q cannot be renamed", t.Name)
+	}
+	switch t.Kind {
+	case ToolKindTemplate:
+		if t.Template == "" {
+			return fmt.Errorf("custom tool %q of kind %s requires a template", t.Name, t.Kind)
+		}
+	case ToolKindSearch:
+		if t.Limit < 0 {
+			return fmt.Errorf("custom tool %q has a negative limit", t.Name)
+		}
+	case ToolKindRead:
+		if t.URI == "" {
+			return fmt.Errorf("custom tool %q of kind %s requires a uri", t.Name, t.Kind)
+		}
+	default:
+		return fmt.Errorf("custom tool %q has unsupported kind %q, must be %s, %s or %s", t.Name, t.Kind, ToolKindTemplate, ToolKindSearch, ToolKindRead)
+	}
+	return nil
+}
+
+// ResourceTemplateMetadata represents a resource template definition in mcp-metadata.yaml
+type ResourceTemplateMetadata struct {
+	URITemplate string `yaml:"uri_template"`
 	Name        string `yaml:"name"`
 	Description string `yaml:"description"`
 }
 
 // McpMetadata represents the root of mcp-metadata.yaml
 type McpMetadata struct {
-	Server ServerMetadata `yaml:"server"`
-	Tools  []ToolMetadata `yaml:"tools"`
+	Server            ServerMetadata             `yaml:"server"`
+	Tools             []ToolMetadata             `yaml:"tools"`
+	ResourceTemplates []ResourceTemplateMetadata `yaml:"resource_templates"`
 }
 
 // DefaultToolMetadata provides sensible defaults for known tools
@@ -41,6 +135,22 @@ WHEN TO USE: Use after you have found a relevant resource URI (e.g., via the sea
 
 HOW IT WORKS: Provide the URI of the resource you wish to read (e.g., 'acdc://guides/getting-started.md'). The tool returns the full markdown content of the resource with frontmatter removed.`,
 	},
+	"read_many": {
+		Name: "read_many",
+		Description: `Read the full content of several resources in one call. This tool works like the read tool but accepts a list of URIs.
+
+WHEN TO USE: Use when you need multiple related resources (e.g., several standards found via the search tool) to save round trips.
+
+HOW IT WORKS: Provide the URIs of the resources you wish to read. Each resource is returned as a separate item with its URI, in the requested order. A URI that cannot be read is reported inline without failing the others. The total size per call is limited; resources beyond the limit are reported as skipped and can be fetched with the read tool.`,
+	},
+	"browse": {
+		Name: "browse",
+		Description: `Browse the available development resources as a tree of folders with resource names and descriptions.
+
+WHEN TO USE: Use to discover what documentation exists, or to list every resource in an area (e.g., 'acdc://guides/') when you don't know what to search for.
+
+HOW IT WORKS: Lists resources under an optional URI prefix, grouped by folder. Limit the depth to get an overview; folders beyond the depth show their resource count. Long listings are paginated: call again with the offset given at the end of the result to continue. Read a listed resource with the read tool.`,
+	},
 }
 
 // GetToolMetadata returns metadata for the specified tool name, using overrides if provided
@@ -48,12 +158,45 @@ HOW IT WORKS: Provide the URI of the resource you wish to read (e.g., 'acdc://gu
 func (m *McpMetadata) GetToolMetadata(name string) ToolMetadata {
 	for _, t := range m.Tools {
 		if t.Name == name {
+			if t.Description == "" {
+				t.Description = DefaultToolMetadata[name].Description
+			}
 			return t
 		}
 	}
 	return DefaultToolMetadata[name]
 }
 
+// builtinToolNames returns the names of the built-in tools, sorted
+func builtinToolNames() []string {
+	names := make([]string, 0, len(DefaultToolMetadata))
+	for name := range DefaultToolMetadata {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ExposedBuiltinToolNames returns the names the enabled built-in tools are
+// registered with
+func (m *McpMetadata) ExposedBuiltinToolNames() []string {
+	var names []string
+	for _, name := range builtinToolNames() {
+		if t := m.GetToolMetadata(name); t.IsEnabled() {
+			names = append(names, t.ExposedName())
+		}
+	}
+	return names
+}
+
+// ValidateToolName checks that a tool name is accepted by MCP clients
+func ValidateToolName(name string) error {
+	if !toolNameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid tool name %q, must be 1-128 letters, digits, '_', '-' or '.'", name)
+	}
+	return nil
+}
+
 // ToolsMap returns tools as a map for easy lookup
 func (m *McpMetadata) ToolsMap() (map[string]ToolMetadata, error) {
 	tools := make(map[string]ToolMetadata)
@@ -66,6 +209,40 @@ func (m *McpMetadata) ToolsMap() (map[string]ToolMetadata, error) {
 	return tools, nil
 }
 
+// validateExposedToolNames checks that the enabled built-in and custom tools
+// are registered with valid, unique names
+func (m *McpMetadata) validateExposedToolNames() error {
+	exposed := make(map[string]string)
+	add := func(t ToolMetadata) error {
+		if !t.IsEnabled() {
+			return nil
+		}
+		name := t.ExposedName()
+		if err := ValidateToolName(name); err != nil {
+			return err
+		}
+		if other, exists := exposed[name]; exists {
+			return fmt.Errorf("tool name %q is used by both %s and %s", name, other, t.Name)
+		}
+		exposed[name] = t.Name
+		return nil
+	}
+
+	for _, name := range builtinToolNames() {
+		if err := add(m.GetToolMetadata(name)); err != nil {
+			return err
+		}
+	}
+	for _, t := range m.Tools {
+		if t.IsCustom() {
+			if err := add(t); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // Validate checks for required fields
 func (m *McpMetadata) Validate() error {
 	if m.Server.Name == "" {
@@ -77,19 +254,52 @@ func (m *McpMetadata) Validate() error {
 	if m.Server.Instructions == "" {
 		return fmt.Errorf("server instructions are required")
 	}
+	if m.Server.InstructionsTemplate {
+		if _, err := template.New("instructions").Parse(m.Server.Instructions); err != nil {
+			return fmt.Errorf("invalid server instructions template: %w", err)
+		}
+	}
 
 	for i, t := range m.Tools {
 		if t.Name == "" {
 			return fmt.Errorf("tool at index %d missing name", i)
 		}
-		if t.Description == "" {
+		// Disabled built-in tools are not exposed and need no description
+		if t.Description == "" && (t.IsCustom() || t.IsEnabled()) {
 			return fmt.Errorf("tool at index %d missing description", i)
 		}
+		if t.IsCustom() {
+			if err := t.ValidateCustomTool(); err != nil {
+				return err
+			}
+		} else if _, builtin := DefaultToolMetadata[t.Name]; !builtin {
+			return fmt.Errorf("unknown tool %q, must be one of %s or a custom tool with a kind", t.Name, strings.Join(builtinToolNames(), ", "))
+		}
 	}
 
 	if _, err := m.ToolsMap(); err != nil {
 		return err
 	}
+	if err := m.validateExposedToolNames(); err != nil {
+		return err
+	}
+
+	uriTemplates := make(map[string]bool)
+	for i, t := range m.ResourceTemplates {
+		if t.URITemplate == "" {
+			return fmt.Errorf("resource template at index %d missing uri_template", i)
+		}
+		if t.Name == "" {
+			return fmt.Errorf("resource template at index %d missing name", i)
+		}
+		if _, err := uritemplate.New(t.URITemplate); err != nil {
+			return fmt.Errorf("resource template at index %d has invalid uri_template: %w", i, err)
+		}
+		if uriTemplates[t.URITemplate] {
+			return fmt.Errorf("duplicate resource template: %s", t.URITemplate)
+		}
+		uriTemplates[t.URITemplate] = true
+	}
 
 	return nil
 }
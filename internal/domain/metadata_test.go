@@ -1,6 +1,9 @@
 package domain
 
-import "testing"
+import (
+	"fmt"
+	"testing"
+)
 
 func TestMcpMetadata_Validate(t *testing.T) {
 	tests := []struct {
@@ -12,7 +15,7 @@ func TestMcpMetadata_Validate(t *testing.T) {
 			name: "Valid",
 			meta: McpMetadata{
 				Server: ServerMetadata{Name: "s", Version: "1", Instructions: "i"},
-				Tools:  []ToolMetadata{{Name: "t", Description: "d"}},
+				Tools:  []ToolMetadata{{Name: "search", Description: "d"}},
 			},
 			wantErr: false,
 		},
@@ -37,6 +40,27 @@ func TestMcpMetadata_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Instructions Template",
+			meta: McpMetadata{
+				Server: ServerMetadata{Name: "s", Version: "1", Instructions: "Areas: {{range .Areas}}{{.Name}} {{end}}", InstructionsTemplate: true},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Invalid Instructions Template",
+			meta: McpMetadata{
+				Server: ServerMetadata{Name: "s", Version: "1", Instructions: "{{range .Areas}}", InstructionsTemplate: true},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Template Syntax In Plain Instructions",
+			meta: McpMetadata{
+				Server: ServerMetadata{Name: "s", Version: "1", Instructions: "Prompts use {{range .Areas}} syntax"},
+			},
+			wantErr: false,
+		},
 		{
 			name: "Missing Tool Name",
 			meta: McpMetadata{
@@ -61,6 +85,174 @@ func TestMcpMetadata_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Valid Resource Template",
+			meta: McpMetadata{
+				Server:            ServerMetadata{Name: "s", Version: "1", Instructions: "i"},
+				ResourceTemplates: []ResourceTemplateMetadata{{URITemplate: "acdc://runbooks/{service}", Name: "n"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Resource Template Missing URI Template",
+			meta: McpMetadata{
+				Server:            ServerMetadata{Name: "s", Version: "1", Instructions: "i"},
+				ResourceTemplates: []ResourceTemplateMetadata{{Name: "n"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Resource Template Missing Name",
+			meta: McpMetadata{
+				Server:            ServerMetadata{Name: "s", Version: "1", Instructions: "i"},
+				ResourceTemplates: []ResourceTemplateMetadata{{URITemplate: "acdc://runbooks/{service}"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Resource Template Invalid URI Template",
+			meta: McpMetadata{
+				Server:            ServerMetadata{Name: "s", Version: "1", Instructions: "i"},
+				ResourceTemplates: []ResourceTemplateMetadata{{URITemplate: "acdc://runbooks/{service", Name: "n"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Duplicate Resource Template",
+			meta: McpMetadata{
+				Server: ServerMetadata{Name: "s", Version: "1", Instructions: "i"},
+				ResourceTemplates: []ResourceTemplateMetadata{
+					{URITemplate: "acdc://runbooks/{service}", Name: "a"},
+					{URITemplate: "acdc://runbooks/{service}", Name: "b"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Valid Custom Tools",
+			meta: McpMetadata{
+				Server: ServerMetadata{Name: "s", Version: "1", Instructions: "i"},
+				Tools: []ToolMetadata{
+					{Name: "checklist", Description: "d", Kind: ToolKindTemplate, Template: "- {{.area}}"},
+					{Name: "lookup", Description: "d", Kind: ToolKindSearch, URIPrefix: "acdc://runbooks/", Limit: 5},
+					{Name: "runbook", Description: "d", Kind: ToolKindRead, URI: "acdc://runbooks/{{.service}}"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Custom Tool Unsupported Kind",
+			meta: McpMetadata{
+				Server: ServerMetadata{Name: "s", Version: "1", Instructions: "i"},
+				Tools:  []ToolMetadata{{Name: "t", Description: "d", Kind: "exec"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Custom Template Tool Missing Template",
+			meta: McpMetadata{
+				Server: ServerMetadata{Name: "s", Version: "1", Instructions: "i"},
+				Tools:  []ToolMetadata{{Name: "t", Description: "d", Kind: ToolKindTemplate}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Custom Read Tool Missing URI",
+			meta: McpMetadata{
+				Server: ServerMetadata{Name: "s", Version: "1", Instructions: "i"},
+				Tools:  []ToolMetadata{{Name: "t", Description: "d", Kind: ToolKindRead}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Custom Search Tool Negative Limit",
+			meta: McpMetadata{
+				Server: ServerMetadata{Name: "s", Version: "1", Instructions: "i"},
+				Tools:  []ToolMetadata{{Name: "t", Description: "d", Kind: ToolKindSearch, Limit: -1}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Custom Tool Named Like Built-in Tool",
+			meta: McpMetadata{
+				Server: ServerMetadata{Name: "s", Version: "1", Instructions: "i"},
+				Tools:  []ToolMetadata{{Name: "search", Description: "d", Kind: ToolKindSearch}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unknown Tool Name",
+			meta: McpMetadata{
+				Server: ServerMetadata{Name: "s", Version: "1", Instructions: "i"},
+				Tools:  []ToolMetadata{{Name: "serach", Description: "d"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Disabled Tool Without Description",
+			meta: McpMetadata{
+				Server: ServerMetadata{Name: "s", Version: "1", Instructions: "i"},
+				Tools:  []ToolMetadata{{Name: "read", Enabled: boolPtr(false)}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Renamed Tools With Annotations",
+			meta: McpMetadata{
+				Server: ServerMetadata{Name: "s", Version: "1", Instructions: "i"},
+				Tools: []ToolMetadata{
+					{Name: "search", Description: "d", Rename: "docs_search", Annotations: &ToolAnnotations{Title: "Docs Search", IdempotentHint: boolPtr(false)}},
+					{Name: "read", Description: "d", Rename: "search"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Rename Collides With Built-in Tool",
+			meta: McpMetadata{
+				Server: ServerMetadata{Name: "s", Version: "1", Instructions: "i"},
+				Tools:  []ToolMetadata{{Name: "read", Description: "d", Rename: "search"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Rename Collides With Disabled Built-in Tool",
+			meta: McpMetadata{
+				Server: ServerMetadata{Name: "s", Version: "1", Instructions: "i"},
+				Tools: []ToolMetadata{
+					{Name: "search", Enabled: boolPtr(false)},
+					{Name: "read", Description: "d", Rename: "search"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Rename Collides With Custom Tool",
+			meta: McpMetadata{
+				Server: ServerMetadata{Name: "s", Version: "1", Instructions: "i"},
+				Tools: []ToolMetadata{
+					{Name: "read", Description: "d", Rename: "lookup"},
+					{Name: "lookup", Description: "d", Kind: ToolKindSearch},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Invalid Rename",
+			meta: McpMetadata{
+				Server: ServerMetadata{Name: "s", Version: "1", Instructions: "i"},
+				Tools:  []ToolMetadata{{Name: "search", Description: "d", Rename: "docs search"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Renamed Custom Tool",
+			meta: McpMetadata{
+				Server: ServerMetadata{Name: "s", Version: "1", Instructions: "i"},
+				Tools:  []ToolMetadata{{Name: "lookup", Description: "d", Kind: ToolKindSearch, Rename: "find"}},
+			},
+			wantErr: true,
+		},
 		{
 			name: "Valid with no tools",
 			meta: McpMetadata{
@@ -116,6 +308,17 @@ func TestGetToolMetadata(t *testing.T) {
 		}
 	})
 
+	t.Run("Default Description", func(t *testing.T) {
+		meta := McpMetadata{Tools: []ToolMetadata{{Name: "read", Enabled: boolPtr(false)}}}
+		got := meta.GetToolMetadata("read")
+		if got.Description != DefaultToolMetadata["read"].Description {
+			t.Errorf("expected default read description, got %s", got.Description)
+		}
+		if got.IsEnabled() {
+			t.Errorf("expected read to be disabled")
+		}
+	})
+
 	t.Run("Empty Tools", func(t *testing.T) {
 		emptyMeta := McpMetadata{}
 		got := emptyMeta.GetToolMetadata("search")
@@ -166,3 +369,27 @@ func TestToolsMap(t *testing.T) {
 		}
 	})
 }
+
+func TestToolMetadata_ExposedName(t *testing.T) {
+	if got := (ToolMetadata{Name: "search"}).ExposedName(); got != "search" {
+		t.Errorf("expected search, got %s", got)
+	}
+	if got := (ToolMetadata{Name: "search", Rename: "docs_search"}).ExposedName(); got != "docs_search" {
+		t.Errorf("expected docs_search, got %s", got)
+	}
+}
+
+func TestMcpMetadata_ExposedBuiltinToolNames(t *testing.T) {
+	m := McpMetadata{Tools: []ToolMetadata{
+		{Name: "search", Description: "d", Rename: "docs_search"},
+		{Name: "browse", Enabled: boolPtr(false)},
+	}}
+	got := fmt.Sprint(m.ExposedBuiltinToolNames())
+	if got != "[read read_many docs_search]" {
+		t.Errorf("unexpected exposed names: %s", got)
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -1,17 +1,25 @@
 package domain
 
+import "time"
+
 // Field name constants for indexed documents
 const (
-	FieldURI      = "uri"
-	FieldName     = "name"
-	FieldContent  = "content"
-	FieldKeywords = "keywords"
+	FieldURI        = "uri"
+	FieldName       = "name"
+	FieldContent    = "content"
+	FieldKeywords   = "keywords"
+	FieldPriority   = "priority"
+	FieldUpdated    = "updated"
+	FieldDeprecated = "deprecated"
 )
 
 // Document represents a document to index
 type Document struct {
-	URI      string   `json:"uri"`
-	Name     string   `json:"name"`
-	Content  string   `json:"content"`
-	Keywords []string `json:"keywords,omitempty"`
+	URI        string     `json:"uri"`
+	Name       string     `json:"name"`
+	Content    string     `json:"content"`
+	Keywords   []string   `json:"keywords,omitempty"`
+	Priority   float64    `json:"priority,omitempty"`
+	Updated    *time.Time `json:"updated,omitempty"`
+	Deprecated bool       `json:"deprecated,omitempty"`
 }
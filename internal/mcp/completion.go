@@ -0,0 +1,65 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sha1n/mcp-acdc-server/internal/prompts"
+	"github.com/sha1n/mcp-acdc-server/internal/resources"
+)
+
+// maxCompletionValues is the maximum number of values in a completion result,
+// as defined by the MCP specification
+const maxCompletionValues = 100
+
+// Completion reference types
+const (
+	completionRefPrompt   = "ref/prompt"
+	completionRefResource = "ref/resource"
+)
+
+func makeCompletionHandler(
+	resourceProvider *resources.ResourceProvider,
+	promptProvider *prompts.PromptProvider,
+) func(context.Context, *mcp.CompleteRequest) (*mcp.CompleteResult, error) {
+	return func(ctx context.Context, req *mcp.CompleteRequest) (*mcp.CompleteResult, error) {
+		ref := req.Params.Ref
+		if ref == nil {
+			return nil, fmt.Errorf("completion reference is required")
+		}
+		argument := req.Params.Argument
+		var resolved map[string]string
+		if req.Params.Context != nil {
+			resolved = req.Params.Context.Arguments
+		}
+
+		var values []string
+		var err error
+		switch ref.Type {
+		case completionRefPrompt:
+			values, err = promptProvider.Complete(ref.Name, argument.Name, argument.Value, resolved)
+		case completionRefResource:
+			values, err = resourceProvider.CompleteTemplateArgument(ref.URI, argument.Name, argument.Value, resolved)
+		default:
+			err = fmt.Errorf("unsupported completion reference type: %s", ref.Type)
+		}
+		if err != nil {
+			slog.Error("Completion failed", "ref", ref.Type, "argument", argument.Name, "error", err)
+			return nil, err
+		}
+
+		total := len(values)
+		if total > maxCompletionValues {
+			values = values[:maxCompletionValues]
+		}
+		return &mcp.CompleteResult{
+			Completion: mcp.CompletionResultDetails{
+				Values:  values,
+				Total:   total,
+				HasMore: total > maxCompletionValues,
+			},
+		}, nil
+	}
+}
@@ -0,0 +1,90 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sha1n/mcp-acdc-server/internal/prompts"
+	"github.com/sha1n/mcp-acdc-server/internal/resources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMakeCompletionHandler(t *testing.T) {
+	manyValues := make([]string, maxCompletionValues+5)
+	for i := range manyValues {
+		manyValues[i] = fmt.Sprintf("v%03d", i)
+	}
+
+	resourceProvider := resources.NewResourceProvider(
+		[]resources.ResourceDefinition{{URI: "acdc://runbooks/payments"}},
+		resources.WithTemplates([]resources.ResourceTemplateDefinition{{URITemplate: "acdc://runbooks/{service}"}}),
+	)
+	promptProvider := prompts.NewPromptProvider([]prompts.PromptDefinition{{
+		Name: "check-config",
+		Arguments: []prompts.PromptArgument{
+			{Name: "setting", Completion: &prompts.CompletionSource{Values: []string{"timeout", "retries"}}},
+			{Name: "many", Completion: &prompts.CompletionSource{Values: manyValues}},
+			{Name: "runbook", Completion: &prompts.CompletionSource{URIPrefix: "acdc://runbooks/{{.service}}"}},
+		},
+	}}, nil, prompts.WithResources(resourceProvider))
+
+	handler := makeCompletionHandler(resourceProvider, promptProvider)
+	complete := func(ref *mcp.CompleteReference, name, value string) (*mcp.CompleteResult, error) {
+		return handler(context.Background(), &mcp.CompleteRequest{Params: &mcp.CompleteParams{
+			Ref:      ref,
+			Argument: mcp.CompleteParamsArgument{Name: name, Value: value},
+		}})
+	}
+
+	t.Run("Prompt", func(t *testing.T) {
+		result, err := complete(&mcp.CompleteReference{Type: "ref/prompt", Name: "check-config"}, "setting", "re")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"retries"}, result.Completion.Values)
+		assert.Equal(t, 1, result.Completion.Total)
+		assert.False(t, result.Completion.HasMore)
+	})
+
+	t.Run("PromptContext", func(t *testing.T) {
+		result, err := handler(context.Background(), &mcp.CompleteRequest{Params: &mcp.CompleteParams{
+			Ref:      &mcp.CompleteReference{Type: "ref/prompt", Name: "check-config"},
+			Argument: mcp.CompleteParamsArgument{Name: "runbook"},
+			Context:  &mcp.CompleteContext{Arguments: map[string]string{"service": "pay"}},
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"acdc://runbooks/payments"}, result.Completion.Values)
+
+		result, err = handler(context.Background(), &mcp.CompleteRequest{Params: &mcp.CompleteParams{
+			Ref:      &mcp.CompleteReference{Type: "ref/prompt", Name: "check-config"},
+			Argument: mcp.CompleteParamsArgument{Name: "runbook"},
+			Context:  &mcp.CompleteContext{Arguments: map[string]string{"service": "web"}},
+		}})
+		require.NoError(t, err)
+		assert.Empty(t, result.Completion.Values)
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		result, err := complete(&mcp.CompleteReference{Type: "ref/prompt", Name: "check-config"}, "many", "")
+		require.NoError(t, err)
+		assert.Len(t, result.Completion.Values, maxCompletionValues)
+		assert.Equal(t, maxCompletionValues+5, result.Completion.Total)
+		assert.True(t, result.Completion.HasMore)
+	})
+
+	t.Run("ResourceTemplate", func(t *testing.T) {
+		result, err := complete(&mcp.CompleteReference{Type: "ref/resource", URI: "acdc://runbooks/{service}"}, "service", "p")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"payments"}, result.Completion.Values)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		_, err := complete(&mcp.CompleteReference{Type: "ref/prompt", Name: "missing"}, "x", "")
+		assert.Error(t, err)
+		_, err = complete(&mcp.CompleteReference{Type: "ref/other"}, "x", "")
+		assert.Error(t, err)
+		_, err = complete(nil, "x", "")
+		assert.Error(t, err)
+	})
+}
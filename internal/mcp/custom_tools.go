@@ -0,0 +1,54 @@
+package mcp
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sha1n/mcp-acdc-server/internal/tools"
+)
+
+// RegisterCustomTools registers the custom tools defined in content and
+// returns the names of the registered tools. Tools whose name is already used
+// by another tool are skipped.
+func RegisterCustomTools(s *mcp.Server, toolProvider *tools.ToolProvider, reserved map[string]bool) []string {
+	var names []string
+	for _, t := range toolProvider.ListTools() {
+		if reserved[t.Name] {
+			slog.Warn("Skipping custom tool with conflicting name", "name", t.Name)
+			continue
+		}
+
+		mcp.AddTool(s,
+			&mcp.Tool{
+				Name:        t.Name,
+				Description: t.Description,
+				InputSchema: t.InputSchema,
+				Annotations: newToolAnnotations(t.Annotations),
+			},
+			NewCustomToolHandler(toolProvider, t.Name),
+		)
+		names = append(names, t.Name)
+		slog.Info("Registered tool", "name", t.Name, "kind", t.Kind)
+	}
+	return names
+}
+
+// NewCustomToolHandler creates the handler for a custom tool
+func NewCustomToolHandler(toolProvider *tools.ToolProvider, name string) mcp.ToolHandlerFor[map[string]any, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+		slog.Info("Custom tool request", "name", name)
+
+		text, err := toolProvider.CallTool(name, args)
+		if err != nil {
+			slog.Error("Custom tool failed", "name", name, "error", err)
+			return nil, nil, err
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: text},
+			},
+		}, nil, nil
+	}
+}
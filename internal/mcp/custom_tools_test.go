@@ -0,0 +1,40 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+	"github.com/sha1n/mcp-acdc-server/internal/tools"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomToolHandler(t *testing.T) {
+	defs, err := tools.FromMetadata([]domain.ToolMetadata{
+		{Name: "checklist", Description: "d", Kind: domain.ToolKindTemplate, Template: "Checklist for {{.service}}"},
+	})
+	require.NoError(t, err)
+	handler := NewCustomToolHandler(tools.NewToolProvider(defs), "checklist")
+
+	result, _, err := handler(context.Background(), &mcp.CallToolRequest{}, map[string]any{"service": "api"})
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	assert.Equal(t, "Checklist for api", result.Content[0].(*mcp.TextContent).Text)
+
+	_, _, err = NewCustomToolHandler(tools.NewToolProvider(defs), "missing")(context.Background(), &mcp.CallToolRequest{}, nil)
+	assert.ErrorContains(t, err, "unknown tool: missing")
+}
+
+func TestRegisterCustomTools(t *testing.T) {
+	defs, err := tools.FromMetadata([]domain.ToolMetadata{
+		{Name: "checklist", Description: "d", Kind: domain.ToolKindTemplate, Template: "x"},
+		{Name: "taken", Description: "d", Kind: domain.ToolKindTemplate, Template: "x"},
+	})
+	require.NoError(t, err)
+
+	s := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1"}, nil)
+	names := RegisterCustomTools(s, tools.NewToolProvider(defs), map[string]bool{"taken": true})
+	assert.Equal(t, []string{"checklist"}, names)
+}
@@ -0,0 +1,92 @@
+package mcp
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+	"github.com/sha1n/mcp-acdc-server/internal/prompts"
+	"github.com/sha1n/mcp-acdc-server/internal/resources"
+)
+
+// ResourceArea is a top-level group of resources sharing the first URI path segment
+type ResourceArea struct {
+	Name  string
+	URI   string
+	Count int
+}
+
+// InstructionsData is the data available to the server instructions template
+type InstructionsData struct {
+	Name      string
+	Version   string
+	Areas     []ResourceArea
+	Resources []mcp.Resource
+	Prompts   []mcp.Prompt
+}
+
+// NewInstructionsData collects the template data for the server instructions
+func NewInstructionsData(
+	metadata domain.McpMetadata,
+	resourceProvider *resources.ResourceProvider,
+	promptProvider *prompts.PromptProvider,
+) InstructionsData {
+	resourceList := resourceProvider.ListResources()
+	return InstructionsData{
+		Name:      metadata.Server.Name,
+		Version:   metadata.Server.Version,
+		Areas:     resourceAreas(resourceList),
+		Resources: resourceList,
+		Prompts:   promptProvider.ListPrompts(),
+	}
+}
+
+// RenderInstructions executes the server instructions as a text template. It
+// is only used for instructions that opt in with instructions_template.
+func RenderInstructions(instructions string, data InstructionsData) (string, error) {
+	tmpl, err := template.New("instructions").Parse(instructions)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse instructions template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to execute instructions template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// resourceAreas groups resources by the first path segment of their URI.
+// Resources at the root of the content directory do not belong to an area.
+func resourceAreas(resourceList []mcp.Resource) []ResourceArea {
+	areaMap := make(map[string]*ResourceArea)
+	for _, res := range resourceList {
+		scheme, path, ok := strings.Cut(res.URI, "://")
+		if !ok {
+			continue
+		}
+		name, _, ok := strings.Cut(path, "/")
+		if !ok || name == "" {
+			continue
+		}
+		area, exists := areaMap[name]
+		if !exists {
+			area = &ResourceArea{Name: name, URI: scheme + "://" + name + "/"}
+			areaMap[name] = area
+		}
+		area.Count++
+	}
+
+	areas := make([]ResourceArea, 0, len(areaMap))
+	for _, area := range areaMap {
+		areas = append(areas, *area)
+	}
+	sort.Slice(areas, func(i, j int) bool {
+		return areas[i].Name < areas[j].Name
+	})
+	return areas
+}
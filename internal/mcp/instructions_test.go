@@ -0,0 +1,90 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+	"github.com/sha1n/mcp-acdc-server/internal/prompts"
+	"github.com/sha1n/mcp-acdc-server/internal/resources"
+)
+
+func testInstructionsData() InstructionsData {
+	metadata := domain.McpMetadata{
+		Server: domain.ServerMetadata{Name: "docs", Version: "1.2.3", Instructions: "i"},
+	}
+	resourceProvider := resources.NewResourceProvider([]resources.ResourceDefinition{
+		{URI: "acdc://runbooks/db", Name: "DB", Description: "Database runbook"},
+		{URI: "acdc://guides/start", Name: "Start", Description: "Getting started"},
+		{URI: "acdc://guides/deploy/k8s", Name: "K8s", Description: "Kubernetes"},
+		{URI: "acdc://readme", Name: "Readme", Description: "Root resource"},
+	})
+	promptProvider := prompts.NewPromptProvider([]prompts.PromptDefinition{
+		{Name: "review", Description: "Review code"},
+	}, nil)
+	return NewInstructionsData(metadata, resourceProvider, promptProvider)
+}
+
+func TestNewInstructionsData(t *testing.T) {
+	data := testInstructionsData()
+
+	if data.Name != "docs" || data.Version != "1.2.3" {
+		t.Errorf("Unexpected server info: %s %s", data.Name, data.Version)
+	}
+	if len(data.Resources) != 4 {
+		t.Errorf("Expected 4 resources, got %d", len(data.Resources))
+	}
+	if len(data.Prompts) != 1 {
+		t.Errorf("Expected 1 prompt, got %d", len(data.Prompts))
+	}
+
+	expected := []ResourceArea{
+		{Name: "guides", URI: "acdc://guides/", Count: 2},
+		{Name: "runbooks", URI: "acdc://runbooks/", Count: 1},
+	}
+	if len(data.Areas) != len(expected) {
+		t.Fatalf("Expected %d areas, got %+v", len(expected), data.Areas)
+	}
+	for i, area := range expected {
+		if data.Areas[i] != area {
+			t.Errorf("Area %d: expected %+v, got %+v", i, area, data.Areas[i])
+		}
+	}
+}
+
+func TestRenderInstructions(t *testing.T) {
+	data := testInstructionsData()
+
+	t.Run("Plain Text", func(t *testing.T) {
+		got, err := RenderInstructions("Use the search tool first.", data)
+		if err != nil {
+			t.Fatalf("RenderInstructions failed: %v", err)
+		}
+		if got != "Use the search tool first." {
+			t.Errorf("Unexpected instructions: %q", got)
+		}
+	})
+
+	t.Run("Template", func(t *testing.T) {
+		tmpl := "{{.Name}} covers:{{range .Areas}} {{.Name}} ({{.Count}}){{end}}; prompts:{{range .Prompts}} {{.Name}}{{end}}"
+		got, err := RenderInstructions(tmpl, data)
+		if err != nil {
+			t.Fatalf("RenderInstructions failed: %v", err)
+		}
+		expected := "docs covers: guides (2) runbooks (1); prompts: review"
+		if got != expected {
+			t.Errorf("Expected %q, got %q", expected, got)
+		}
+	})
+
+	t.Run("Parse Error", func(t *testing.T) {
+		if _, err := RenderInstructions("{{.Name", data); err == nil {
+			t.Error("Expected parse error")
+		}
+	})
+
+	t.Run("Execution Error", func(t *testing.T) {
+		if _, err := RenderInstructions("{{.Unknown}}", data); err == nil {
+			t.Error("Expected execution error")
+		}
+	})
+}
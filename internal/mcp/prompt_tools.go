@@ -0,0 +1,162 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+	"github.com/sha1n/mcp-acdc-server/internal/prompts"
+	"github.com/sha1n/mcp-acdc-server/internal/tools"
+)
+
+// RegisterPromptTools registers every prompt as a tool that returns the
+// rendered prompt, for clients that do not support prompts. Prompts whose name
+// is not a valid tool name or is already used by another tool are skipped.
+func RegisterPromptTools(s *mcp.Server, promptProvider *prompts.PromptProvider, reserved map[string]bool) {
+	for _, p := range promptProvider.ListPrompts() {
+		name := p.Name
+		if err := domain.ValidateToolName(name); err != nil {
+			slog.Warn("Skipping prompt tool with invalid name", "name", name, "error", err)
+			continue
+		}
+		if reserved[name] {
+			slog.Warn("Skipping prompt tool with conflicting name", "name", name)
+			continue
+		}
+
+		args, err := promptProvider.Arguments(name)
+		if err != nil {
+			slog.Error("Failed to register prompt tool", "name", name, "error", err)
+			continue
+		}
+		schema, err := NewPromptInputSchema(args)
+		if err != nil {
+			slog.Warn("Skipping prompt tool with invalid input schema", "name", name, "error", err)
+			continue
+		}
+
+		mcp.AddTool(s,
+			&mcp.Tool{
+				Name:        name,
+				Description: p.Description,
+				InputSchema: schema,
+			},
+			NewPromptToolHandler(promptProvider, name),
+		)
+		slog.Info("Registered prompt tool", "name", name)
+	}
+}
+
+// NewPromptInputSchema generates a tool input schema from prompt arguments
+func NewPromptInputSchema(args []prompts.PromptArgument) (*jsonschema.Schema, error) {
+	schema := &jsonschema.Schema{
+		Type:       "object",
+		Properties: make(map[string]*jsonschema.Schema, len(args)),
+	}
+
+	for _, a := range args {
+		prop := &jsonschema.Schema{Description: a.Description}
+		var def any
+		if a.Default != nil {
+			def = *a.Default
+		}
+
+		switch a.Type {
+		case prompts.ArgTypeInt:
+			prop.Type = "integer"
+			if a.Default != nil {
+				n, err := strconv.Atoi(*a.Default)
+				if err != nil {
+					return nil, fmt.Errorf("argument %q: invalid default: %w", a.Name, err)
+				}
+				def = n
+			}
+		case prompts.ArgTypeBool:
+			prop.Type = "boolean"
+			if a.Default != nil {
+				def = *a.Default == "true"
+			}
+		case prompts.ArgTypeEnum:
+			prop.Type = "string"
+			for _, e := range a.Enum {
+				prop.Enum = append(prop.Enum, e)
+			}
+		default:
+			prop.Type = "string"
+			if a.Pattern != nil {
+				prop.Pattern = a.Pattern.String()
+			}
+		}
+
+		if def != nil {
+			raw, err := json.Marshal(def)
+			if err != nil {
+				return nil, fmt.Errorf("argument %q: invalid default: %w", a.Name, err)
+			}
+			prop.Default = raw
+		}
+
+		schema.Properties[a.Name] = prop
+		if a.Required && a.Default == nil {
+			schema.Required = append(schema.Required, a.Name)
+		}
+	}
+
+	return schema, nil
+}
+
+// NewPromptToolHandler creates the handler for a prompt tool
+func NewPromptToolHandler(promptProvider *prompts.PromptProvider, name string) mcp.ToolHandlerFor[map[string]any, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+		slog.Info("Prompt tool request", "name", name)
+
+		messages, err := promptProvider.GetPrompt(name, tools.StringArguments(args))
+		if err != nil {
+			slog.Error("Prompt tool failed", "name", name, "error", err)
+			return nil, nil, err
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: renderPromptMessages(messages)},
+			},
+		}, nil, nil
+	}
+}
+
+// renderPromptMessages flattens prompt messages into a single text. A
+// single-role prompt is returned as its text; otherwise each message is
+// labeled with its role.
+func renderPromptMessages(messages []*mcp.PromptMessage) string {
+	labeled := false
+	for _, m := range messages {
+		if m.Role != messages[0].Role {
+			labeled = true
+			break
+		}
+	}
+
+	parts := make([]string, 0, len(messages))
+	for _, m := range messages {
+		var text string
+		switch c := m.Content.(type) {
+		case *mcp.TextContent:
+			text = c.Text
+		case *mcp.EmbeddedResource:
+			if c.Resource != nil {
+				text = c.Resource.Text
+			}
+		}
+		if labeled {
+			text = fmt.Sprintf("[%s]\n%s", m.Role, text)
+		}
+		parts = append(parts, text)
+	}
+	return strings.Join(parts, "\n\n")
+}
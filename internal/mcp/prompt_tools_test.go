@@ -0,0 +1,118 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"testing"
+	"text/template"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sha1n/mcp-acdc-server/internal/prompts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPromptInputSchema(t *testing.T) {
+	limit, verbose := "5", "true"
+	schema, err := NewPromptInputSchema([]prompts.PromptArgument{
+		{Name: "topic", Description: "Topic", Required: true, Type: prompts.ArgTypeString, Pattern: regexp.MustCompile(`^\w+$`)},
+		{Name: "limit", Description: "Max items", Required: true, Type: prompts.ArgTypeInt, Default: &limit},
+		{Name: "verbose", Type: prompts.ArgTypeBool, Default: &verbose},
+		{Name: "format", Type: prompts.ArgTypeEnum, Enum: []string{"markdown", "json"}},
+		{Name: "note"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "object", schema.Type)
+	assert.Equal(t, []string{"topic"}, schema.Required, "arguments with a default are optional")
+
+	assert.Equal(t, "string", schema.Properties["topic"].Type)
+	assert.Equal(t, `^\w+$`, schema.Properties["topic"].Pattern)
+	assert.Equal(t, "Topic", schema.Properties["topic"].Description)
+
+	assert.Equal(t, "integer", schema.Properties["limit"].Type)
+	assert.JSONEq(t, "5", string(schema.Properties["limit"].Default))
+
+	assert.Equal(t, "boolean", schema.Properties["verbose"].Type)
+	assert.JSONEq(t, "true", string(schema.Properties["verbose"].Default))
+
+	assert.Equal(t, "string", schema.Properties["format"].Type)
+	assert.Equal(t, []any{"markdown", "json"}, schema.Properties["format"].Enum)
+
+	assert.Equal(t, "string", schema.Properties["note"].Type)
+}
+
+func TestRenderPromptMessages(t *testing.T) {
+	t.Run("SingleRole", func(t *testing.T) {
+		text := renderPromptMessages([]*mcp.PromptMessage{
+			{Role: "user", Content: &mcp.TextContent{Text: "First"}},
+			{Role: "user", Content: &mcp.EmbeddedResource{Resource: &mcp.ResourceContents{URI: "acdc://a", Text: "Embedded"}}},
+		})
+		assert.Equal(t, "First\n\nEmbedded", text)
+	})
+
+	t.Run("MultipleRoles", func(t *testing.T) {
+		text := renderPromptMessages([]*mcp.PromptMessage{
+			{Role: "user", Content: &mcp.TextContent{Text: "Question"}},
+			{Role: "assistant", Content: &mcp.TextContent{Text: "Answer"}},
+		})
+		assert.Equal(t, "[user]\nQuestion\n\n[assistant]\nAnswer", text)
+	})
+}
+
+func TestPromptToolHandler(t *testing.T) {
+	tmpl := template.Must(template.New("report").Option("missingkey=zero").Parse("Top {{.limit}} in {{.area}}, verbose={{.verbose}}"))
+	provider := prompts.NewPromptProvider([]prompts.PromptDefinition{{
+		Name:     "report",
+		Template: tmpl,
+		Arguments: []prompts.PromptArgument{
+			{Name: "area", Required: true, Type: prompts.ArgTypeString},
+			{Name: "limit", Required: true, Type: prompts.ArgTypeInt},
+			{Name: "verbose", Type: prompts.ArgTypeBool},
+		},
+	}}, nil)
+	handler := NewPromptToolHandler(provider, "report")
+
+	var args map[string]any
+	require.NoError(t, json.Unmarshal([]byte(`{"area": "billing", "limit": 3, "verbose": true}`), &args))
+
+	result, _, err := handler(context.Background(), &mcp.CallToolRequest{}, args)
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	assert.Equal(t, "Top 3 in billing, verbose=true", result.Content[0].(*mcp.TextContent).Text)
+
+	_, _, err = handler(context.Background(), &mcp.CallToolRequest{}, map[string]any{"limit": "many"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing required argument: area")
+	assert.Contains(t, err.Error(), "limit: must be an integer")
+}
+
+func TestRegisterPromptTools(t *testing.T) {
+	var defs []prompts.PromptDefinition
+	for _, name := range []string{"review", "Code Review", "review/pr", "search"} {
+		defs = append(defs, prompts.PromptDefinition{Name: name, Template: template.Must(template.New(name).Parse("Hi"))})
+	}
+	provider := prompts.NewPromptProvider(defs, nil)
+
+	s := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1"}, nil)
+	RegisterPromptTools(s, provider, map[string]bool{"search": true})
+
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := s.Connect(ctx, serverTransport, nil)
+	require.NoError(t, err)
+	defer func() { _ = serverSession.Close() }()
+	client := mcp.NewClient(&mcp.Implementation{Name: "client", Version: "1"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	defer func() { _ = session.Close() }()
+
+	result, err := session.ListTools(ctx, nil)
+	require.NoError(t, err)
+	var names []string
+	for _, tool := range result.Tools {
+		names = append(names, tool.Name)
+	}
+	assert.Equal(t, []string{"review"}, names)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/sha1n/mcp-acdc-server/internal/prompts"
 	"github.com/sha1n/mcp-acdc-server/internal/resources"
 	"github.com/sha1n/mcp-acdc-server/internal/search"
+	"github.com/sha1n/mcp-acdc-server/internal/tools"
 )
 
 const (
@@ -15,33 +16,82 @@ const (
 	ToolNameSearch = "search"
 	// ToolNameRead is the name of the read tool
 	ToolNameRead = "read"
+	// ToolNameReadMany is the name of the read_many tool
+	ToolNameReadMany = "read_many"
+	// ToolNameBrowse is the name of the browse tool
+	ToolNameBrowse = "browse"
 )
 
+// Option configures optional server features
+type Option func(*serverOptions)
+
+type serverOptions struct {
+	promptTools     bool
+	customTools     *tools.ToolProvider
+	readManyMaxSize int
+}
+
+// WithCustomTools registers the custom tools of toolProvider
+func WithCustomTools(toolProvider *tools.ToolProvider) Option {
+	return func(o *serverOptions) {
+		o.customTools = toolProvider
+	}
+}
+
+// WithPromptTools registers every prompt as a tool as well, for clients that
+// do not support prompts
+func WithPromptTools(enabled bool) Option {
+	return func(o *serverOptions) {
+		o.promptTools = enabled
+	}
+}
+
+// WithReadManyMaxSize limits the total size in bytes of the resources returned
+// by one read_many call. 0, the default, disables the limit.
+func WithReadManyMaxSize(maxBytes int) Option {
+	return func(o *serverOptions) {
+		o.readManyMaxSize = maxBytes
+	}
+}
+
 // CreateServer creates and configures the MCP server
 func CreateServer(
 	metadata domain.McpMetadata,
 	resourceProvider *resources.ResourceProvider,
 	promptProvider *prompts.PromptProvider,
 	searchService search.Searcher,
+	opts ...Option,
 ) *mcp.Server {
+	var options serverOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	// Create server with official SDK
 	s := mcp.NewServer(&mcp.Implementation{
 		Name:    metadata.Server.Name,
 		Version: metadata.Server.Version,
-	}, nil)
-	// Note: Instructions are stored in metadata but not directly supported by official SDK
+	}, &mcp.ServerOptions{
+		Instructions:      metadata.Server.Instructions,
+		CompletionHandler: makeCompletionHandler(resourceProvider, promptProvider),
+	})
 
 	// Register Resources
 	for _, res := range resourceProvider.ListResources() {
-		// Capture uri for closure
-		uri := res.URI
-
-		s.AddResource(&mcp.Resource{
-			URI:         uri,
-			Name:        res.Name,
-			Description: res.Description,
-			MIMEType:    res.MIMEType,
-		}, makeResourceHandler(resourceProvider, uri))
+		s.AddResource(&res, makeResourceHandler(resourceProvider, res.URI))
+	}
+	s.AddReceivingMiddleware(makeAliasMiddleware(resourceProvider))
+
+	// Register Resource Templates
+	for _, t := range resourceProvider.ListResourceTemplates() {
+		s.AddResourceTemplate(&mcp.ResourceTemplate{
+			URITemplate: t.URITemplate,
+			Name:        t.Name,
+			Description: t.Description,
+			MIMEType:    t.MIMEType,
+		}, makeResourceTemplateHandler(resourceProvider))
+
+		slog.Info("Registered resource template", "uri_template", t.URITemplate)
 	}
 
 	// Register Prompts
@@ -59,11 +109,41 @@ func CreateServer(
 	}
 
 	// Register Tools
-	RegisterSearchTool(s, searchService, metadata.GetToolMetadata(ToolNameSearch))
-	slog.Info("Registered tool", "name", ToolNameSearch)
+	registered := make(map[string]bool)
+
+	if searchMeta := metadata.GetToolMetadata(ToolNameSearch); searchMeta.IsEnabled() {
+		RegisterSearchTool(s, searchService, searchMeta)
+		registered[searchMeta.ExposedName()] = true
+		slog.Info("Registered tool", "name", searchMeta.ExposedName())
+	}
+
+	if readMeta := metadata.GetToolMetadata(ToolNameRead); readMeta.IsEnabled() {
+		RegisterReadTool(s, resourceProvider, readMeta)
+		registered[readMeta.ExposedName()] = true
+		slog.Info("Registered tool", "name", readMeta.ExposedName())
+	}
 
-	RegisterReadTool(s, resourceProvider, metadata.GetToolMetadata(ToolNameRead))
-	slog.Info("Registered tool", "name", ToolNameRead)
+	if readManyMeta := metadata.GetToolMetadata(ToolNameReadMany); readManyMeta.IsEnabled() {
+		RegisterReadManyTool(s, resourceProvider, readManyMeta, options.readManyMaxSize)
+		registered[readManyMeta.ExposedName()] = true
+		slog.Info("Registered tool", "name", readManyMeta.ExposedName())
+	}
+
+	if browseMeta := metadata.GetToolMetadata(ToolNameBrowse); browseMeta.IsEnabled() {
+		RegisterBrowseTool(s, resourceProvider, browseMeta)
+		registered[browseMeta.ExposedName()] = true
+		slog.Info("Registered tool", "name", browseMeta.ExposedName())
+	}
+
+	if options.customTools != nil {
+		for _, name := range RegisterCustomTools(s, options.customTools, registered) {
+			registered[name] = true
+		}
+	}
+
+	if options.promptTools {
+		RegisterPromptTools(s, promptProvider, registered)
+	}
 
 	return s
 }
@@ -13,15 +13,97 @@ import (
 func makeResourceHandler(resourceProvider *resources.ResourceProvider, uri string) mcp.ResourceHandler {
 	return func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
 		slog.Info("Resource request", "uri", uri)
+		res, _ := resourceProvider.GetResource(uri)
+		contents := &mcp.ResourceContents{
+			URI:      uri,
+			MIMEType: res.MIMEType,
+			Meta:     resourceMeta(res, resourceProvider.Attachments(uri)),
+		}
+
+		// Attachments are served as-is, as blobs
+		blob, isBlob, err := resourceProvider.ReadBlob(uri)
+		if !isBlob {
+			contents.Text, err = resourceProvider.ReadResource(uri)
+		}
+		if err != nil {
+			slog.Error("Resource read failed", "uri", uri, "error", err)
+			return nil, err
+		}
+		contents.Blob = blob
+
+		return &mcp.ReadResourceResult{
+			Contents: []*mcp.ResourceContents{contents},
+		}, nil
+	}
+}
+
+// resourceMeta returns the _meta of read results, which carries the
+// annotations of the resource listing since resource contents have no
+// annotations of their own, and the URIs of the attachments of the resource
+func resourceMeta(res mcp.Resource, attachments []string) mcp.Meta {
+	meta := mcp.Meta{}
+	if res.Annotations != nil {
+		meta["annotations"] = res.Annotations
+	}
+	if len(attachments) > 0 {
+		meta["attachments"] = attachments
+	}
+	if len(meta) == 0 {
+		return nil
+	}
+	return meta
+}
+
+// metaCanonicalURI is the _meta key of the canonical URI of a resource read
+// through one of its aliases
+const metaCanonicalURI = "canonicalUri"
+
+// makeAliasMiddleware serves resources/read requests for aliases from the
+// resource they resolve to, and notes its canonical URI in the _meta of the
+// contents. Aliases are not listed, so the SDK would otherwise report them as
+// unknown resources.
+func makeAliasMiddleware(resourceProvider *resources.ResourceProvider) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			readReq, ok := req.(*mcp.ReadResourceRequest)
+			if method != "resources/read" || !ok || readReq.Params == nil {
+				return next(ctx, method, req)
+			}
+			canonical, ok := resourceProvider.ResolveAlias(readReq.Params.URI)
+			if !ok {
+				return next(ctx, method, req)
+			}
+
+			slog.Info("Resource alias request", "uri", readReq.Params.URI, "canonical_uri", canonical)
+			result, err := makeResourceHandler(resourceProvider, canonical)(ctx, readReq)
+			if err != nil {
+				return nil, err
+			}
+			for _, c := range result.Contents {
+				if c.Meta == nil {
+					c.Meta = mcp.Meta{}
+				}
+				c.Meta[metaCanonicalURI] = canonical
+			}
+			return result, nil
+		}
+	}
+}
+
+func makeResourceTemplateHandler(resourceProvider *resources.ResourceProvider) mcp.ResourceHandler {
+	return func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		uri := req.Params.URI
+		slog.Info("Resource template request", "uri", uri)
 		content, err := resourceProvider.ReadResource(uri)
 		if err != nil {
 			slog.Error("Resource read failed", "uri", uri, "error", err)
 			return nil, err
 		}
+		mimeType, _ := resourceProvider.MIMEType(uri)
 		return &mcp.ReadResourceResult{
 			Contents: []*mcp.ResourceContents{{
 				URI:      uri,
-				MIMEType: "text/markdown",
+				MIMEType: mimeType,
 				Text:     content,
 			}},
 		}, nil
@@ -184,3 +184,74 @@ func TestMakePromptHandler_Error_MissingRequiredArgument(t *testing.T) {
 	assert.Contains(t, err.Error(), "required")
 	assert.Nil(t, result)
 }
+
+func TestMakeResourceTemplateHandler(t *testing.T) {
+	specPath := filepath.Join(t.TempDir(), "billing.json")
+	require.NoError(t, os.WriteFile(specPath, []byte(`{"openapi": "3.0.0"}`), 0644))
+
+	resourceProvider := resources.NewResourceProvider([]resources.ResourceDefinition{
+		{URI: "acdc://specs/billing.json", Name: "Billing", MIMEType: "application/json", FilePath: specPath},
+	}, resources.WithTemplates([]resources.ResourceTemplateDefinition{
+		{
+			URITemplate: "acdc://services/{name}",
+			Name:        "Service",
+			MIMEType:    resources.MIMETypeMarkdown,
+			Template:    template.Must(template.New("t").Parse("Service {{.name}}")),
+		},
+		{URITemplate: "acdc://specs/{name}", Name: "Spec", MIMEType: resources.MIMETypeMarkdown},
+	}))
+
+	handler := makeResourceTemplateHandler(resourceProvider)
+
+	result, err := handler(context.Background(), &mcp.ReadResourceRequest{
+		Params: &mcp.ReadResourceParams{URI: "acdc://services/checkout"},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Contents, 1)
+	assert.Equal(t, "acdc://services/checkout", result.Contents[0].URI)
+	assert.Equal(t, "Service checkout", result.Contents[0].Text)
+	assert.Equal(t, resources.MIMETypeMarkdown, result.Contents[0].MIMEType)
+
+	// Templates declared in metadata resolve to discovered resources, which
+	// keep their own MIME type
+	result, err = handler(context.Background(), &mcp.ReadResourceRequest{
+		Params: &mcp.ReadResourceParams{URI: "acdc://specs/billing.json"},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Contents, 1)
+	assert.Equal(t, "application/json", result.Contents[0].MIMEType)
+
+	_, err = handler(context.Background(), &mcp.ReadResourceRequest{
+		Params: &mcp.ReadResourceParams{URI: "acdc://other/checkout"},
+	})
+	assert.Error(t, err)
+}
+
+func TestMakeResourceHandler_Attachment(t *testing.T) {
+	tempDir := t.TempDir()
+	pngPath := filepath.Join(tempDir, "diagram.png")
+	require.NoError(t, os.WriteFile(pngPath, []byte("\x89PNG"), 0644))
+	mdPath := filepath.Join(tempDir, "runbook.md")
+	require.NoError(t, os.WriteFile(mdPath, []byte("---\nname: Runbook\ndescription: d\n---\nBody"), 0644))
+
+	resourceProvider := resources.NewResourceProvider([]resources.ResourceDefinition{
+		{URI: "acdc://runbook", Name: "Runbook", MIMEType: "text/markdown", FilePath: mdPath, Attachments: []string{"acdc://diagram.png"}},
+		{URI: "acdc://diagram.png", Name: "Diagram", MIMEType: "image/png", FilePath: pngPath, AttachedTo: "acdc://runbook"},
+	})
+
+	result, err := makeResourceHandler(resourceProvider, "acdc://diagram.png")(context.Background(), &mcp.ReadResourceRequest{
+		Params: &mcp.ReadResourceParams{URI: "acdc://diagram.png"},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Contents, 1)
+	assert.Equal(t, "image/png", result.Contents[0].MIMEType)
+	assert.Equal(t, []byte("\x89PNG"), result.Contents[0].Blob)
+	assert.Empty(t, result.Contents[0].Text)
+
+	result, err = makeResourceHandler(resourceProvider, "acdc://runbook")(context.Background(), &mcp.ReadResourceRequest{
+		Params: &mcp.ReadResourceParams{URI: "acdc://runbook"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Body", result.Contents[0].Text)
+	assert.Equal(t, []string{"acdc://diagram.png"}, result.Contents[0].Meta["attachments"])
+}
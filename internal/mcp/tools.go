@@ -14,7 +14,11 @@ import (
 
 // SearchToolArgument represents arguments for search tool
 type SearchToolArgument struct {
-	Query string `json:"query" jsonschema_description:"The search query. Use natural language or keywords."`
+	Query     string             `json:"query" jsonschema_description:"The search query. Use natural language or keywords."`
+	Limit     int                `json:"limit,omitempty" jsonschema_description:"Maximum number of results to return (defaults to the server setting)."`
+	URIPrefix string             `json:"uri_prefix,omitempty" jsonschema_description:"Only return resources whose URI starts with this prefix, e.g. acdc://guides/"`
+	Boosts    map[string]float64 `json:"boosts,omitempty" jsonschema_description:"Per-field boost overrides for this query. Keys: name, content, keywords."`
+	Explain   bool               `json:"explain,omitempty" jsonschema_description:"Include a per-field score breakdown with each result (for debugging ranking)."`
 }
 
 // ReadToolArgument represents arguments for read tool
@@ -22,12 +26,30 @@ type ReadToolArgument struct {
 	URI string `json:"uri" jsonschema_description:"The acdc:// URI of the resource to fetch"`
 }
 
+// ReadManyToolArgument represents arguments for read_many tool
+type ReadManyToolArgument struct {
+	URIs []string `json:"uris" jsonschema_description:"The acdc:// URIs of the resources to fetch, in the order they should be returned"`
+}
+
+// BrowseToolArgument represents arguments for browse tool
+type BrowseToolArgument struct {
+	URIPrefix string `json:"uri_prefix,omitempty" jsonschema_description:"Only list resources whose URI starts with this prefix, e.g. acdc://guides/ (defaults to all resources)"`
+	Depth     int    `json:"depth,omitempty" jsonschema_description:"Number of folder levels to expand. Deeper folders are listed with their resource count (defaults to all levels)."`
+	Offset    int    `json:"offset,omitempty" jsonschema_description:"Number of entries to skip, to continue a previous listing"`
+	Limit     int    `json:"limit,omitempty" jsonschema_description:"Maximum number of entries to return (defaults to 100)"`
+}
+
+// defaultBrowseLimit is the number of entries returned by the browse tool
+// when no limit is given
+const defaultBrowseLimit = 100
+
 // RegisterSearchTool registers the search tool with the server
 func RegisterSearchTool(s *mcp.Server, searchService search.Searcher, metadata domain.ToolMetadata) {
 	mcp.AddTool(s,
 		&mcp.Tool{
-			Name:        metadata.Name,
+			Name:        metadata.ExposedName(),
 			Description: metadata.Description,
+			Annotations: newToolAnnotations(metadata.Annotations),
 			// InputSchema auto-generated from SearchToolArgument
 		},
 		NewSearchToolHandler(searchService),
@@ -38,39 +60,84 @@ func RegisterSearchTool(s *mcp.Server, searchService search.Searcher, metadata d
 func RegisterReadTool(s *mcp.Server, resourceProvider *resources.ResourceProvider, metadata domain.ToolMetadata) {
 	mcp.AddTool(s,
 		&mcp.Tool{
-			Name:        metadata.Name,
+			Name:        metadata.ExposedName(),
 			Description: metadata.Description,
+			Annotations: newToolAnnotations(metadata.Annotations),
 			// InputSchema auto-generated from ReadToolArgument
 		},
 		NewReadToolHandler(resourceProvider),
 	)
 }
 
+// RegisterReadManyTool registers the read_many tool with the server.
+// maxBytes limits the total size of the returned resources, 0 disables the limit.
+func RegisterReadManyTool(s *mcp.Server, resourceProvider *resources.ResourceProvider, metadata domain.ToolMetadata, maxBytes int) {
+	mcp.AddTool(s,
+		&mcp.Tool{
+			Name:        metadata.ExposedName(),
+			Description: metadata.Description,
+			Annotations: newToolAnnotations(metadata.Annotations),
+			// InputSchema auto-generated from ReadManyToolArgument
+		},
+		NewReadManyToolHandler(resourceProvider, maxBytes),
+	)
+}
+
+// RegisterBrowseTool registers the browse tool with the server
+func RegisterBrowseTool(s *mcp.Server, resourceProvider *resources.ResourceProvider, metadata domain.ToolMetadata) {
+	mcp.AddTool(s,
+		&mcp.Tool{
+			Name:        metadata.ExposedName(),
+			Description: metadata.Description,
+			Annotations: newToolAnnotations(metadata.Annotations),
+			// InputSchema auto-generated from BrowseToolArgument
+		},
+		NewBrowseToolHandler(resourceProvider),
+	)
+}
+
+// newToolAnnotations returns the MCP annotations of a tool. Every tool of the
+// server only reads content, so tools are read-only and idempotent unless the
+// metadata says otherwise.
+func newToolAnnotations(annotations *domain.ToolAnnotations) *mcp.ToolAnnotations {
+	result := &mcp.ToolAnnotations{ReadOnlyHint: true, IdempotentHint: true}
+	if annotations == nil {
+		return result
+	}
+	result.Title = annotations.Title
+	if annotations.ReadOnlyHint != nil {
+		result.ReadOnlyHint = *annotations.ReadOnlyHint
+	}
+	if annotations.IdempotentHint != nil {
+		result.IdempotentHint = *annotations.IdempotentHint
+	}
+	return result
+}
+
 // NewSearchToolHandler creates the handler for the search tool
 func NewSearchToolHandler(searchService search.Searcher) mcp.ToolHandlerFor[SearchToolArgument, any] {
 	return func(ctx context.Context, req *mcp.CallToolRequest, args SearchToolArgument) (*mcp.CallToolResult, any, error) {
 		// Args are already validated and unmarshaled by SDK via jsonschema tags
-		slog.Info("Search request", "query", args.Query)
+		slog.Info("Search request", "query", args.Query, "limit", args.Limit, "uri_prefix", args.URIPrefix, "explain", args.Explain)
+
+		opts := &search.SearchOptions{
+			URIPrefix: args.URIPrefix,
+			Boosts:    args.Boosts,
+			Explain:   args.Explain,
+		}
+		if args.Limit > 0 {
+			opts.Limit = &args.Limit
+		}
 
-		results, err := searchService.Search(args.Query, nil)
+		results, err := searchService.Search(args.Query, opts)
 		if err != nil {
 			slog.Error("Search failed", "query", args.Query, "error", err)
 			return nil, nil, err
 		}
 
-		var sb strings.Builder
-		if len(results) == 0 {
-			sb.WriteString(fmt.Sprintf("No results found for '%s'", args.Query))
-		} else {
-			sb.WriteString(fmt.Sprintf("Search results for '%s':\n\n", args.Query))
-			for _, r := range results {
-				sb.WriteString(fmt.Sprintf("- [%s](%s): %s\n\n", r.Name, r.URI, r.Snippet))
-			}
-		}
-
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
-				&mcp.TextContent{Text: sb.String()},
+				&mcp.TextContent{Text: search.FormatResults(args.Query, results)},
 			},
 		}, nil, nil
 	}
@@ -88,10 +155,137 @@ func NewReadToolHandler(resourceProvider *resources.ResourceProvider) mcp.ToolHa
 			return nil, nil, err
 		}
 
+		text := &mcp.TextContent{Text: content}
+		if res, ok := resourceProvider.GetResource(args.URI); ok {
+			text.Annotations = res.Annotations
+		}
+		if canonical, ok := resourceProvider.ResolveAlias(args.URI); ok {
+			text.Meta = mcp.Meta{metaCanonicalURI: canonical}
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{text},
+		}, nil, nil
+	}
+}
+
+// NewReadManyToolHandler creates the handler for the read_many tool. Every
+// resource is returned as a separate embedded resource. Resources that fail to
+// load, or that would exceed maxBytes in total, are reported as text content in
+// their place instead of failing the call.
+func NewReadManyToolHandler(resourceProvider *resources.ResourceProvider, maxBytes int) mcp.ToolHandlerFor[ReadManyToolArgument, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args ReadManyToolArgument) (*mcp.CallToolResult, any, error) {
+		slog.Info("Read many request", "uris", args.URIs)
+
+		if len(args.URIs) == 0 {
+			return nil, nil, fmt.Errorf("at least one uri is required")
+		}
+
+		result := &mcp.CallToolResult{}
+		seen := make(map[string]bool, len(args.URIs))
+		total := 0
+		for _, uri := range args.URIs {
+			// Aliases are returned under their canonical URI
+			if canonical, ok := resourceProvider.ResolveAlias(uri); ok {
+				uri = canonical
+			}
+			if seen[uri] {
+				continue
+			}
+			seen[uri] = true
+
+			content, err := resourceProvider.ReadResource(uri)
+			if err != nil {
+				slog.Warn("Read many: resource read failed", "uri", uri, "error", err)
+				result.Content = append(result.Content, &mcp.TextContent{Text: fmt.Sprintf("Error reading %s: %v", uri, err)})
+				continue
+			}
+
+			if maxBytes > 0 && total+len(content) > maxBytes {
+				slog.Warn("Read many: size limit reached", "uri", uri, "size", len(content), "limit", maxBytes)
+				result.Content = append(result.Content, &mcp.TextContent{Text: fmt.Sprintf("Skipped %s: its %d bytes would exceed the total size limit of %d bytes per call, read it separately", uri, len(content), maxBytes)})
+				continue
+			}
+			total += len(content)
+
+			embedded := &mcp.EmbeddedResource{
+				Resource: &mcp.ResourceContents{
+					URI:      uri,
+					MIMEType: resources.MIMETypeMarkdown,
+					Text:     content,
+				},
+			}
+			if res, ok := resourceProvider.GetResource(uri); ok {
+				switch {
+				case resourceProvider.IsAttachment(uri):
+					// Attachments are read as their extracted text
+					embedded.Resource.MIMEType = "text/plain"
+				case res.MIMEType != "":
+					embedded.Resource.MIMEType = res.MIMEType
+				}
+				embedded.Annotations = res.Annotations
+			}
+			result.Content = append(result.Content, embedded)
+		}
+
+		return result, nil, nil
+	}
+}
+
+// NewBrowseToolHandler creates the handler for the browse tool
+func NewBrowseToolHandler(resourceProvider *resources.ResourceProvider) mcp.ToolHandlerFor[BrowseToolArgument, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args BrowseToolArgument) (*mcp.CallToolResult, any, error) {
+		slog.Info("Browse request", "uri_prefix", args.URIPrefix, "depth", args.Depth, "offset", args.Offset, "limit", args.Limit)
+
+		if args.Depth < 0 || args.Offset < 0 || args.Limit < 0 {
+			return nil, nil, fmt.Errorf("depth, offset and limit must not be negative")
+		}
+		limit := args.Limit
+		if limit == 0 {
+			limit = defaultBrowseLimit
+		}
+
+		entries := resourceProvider.Browse(args.URIPrefix, args.Depth)
+
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
-				&mcp.TextContent{Text: content},
+				&mcp.TextContent{Text: formatBrowseEntries(args.URIPrefix, entries, args.Offset, limit)},
 			},
 		}, nil, nil
 	}
 }
+
+// formatBrowseEntries renders a page of the resource tree as a nested
+// markdown list, followed by the offset of the next page if there is one
+func formatBrowseEntries(uriPrefix string, entries []resources.BrowseEntry, offset, limit int) string {
+	scope := "all resources"
+	if uriPrefix != "" {
+		scope = fmt.Sprintf("resources under '%s'", uriPrefix)
+	}
+	if len(entries) == 0 {
+		return fmt.Sprintf("No %s found", strings.TrimPrefix(scope, "all "))
+	}
+	if offset >= len(entries) {
+		return fmt.Sprintf("No entries after offset %d, the listing of %s has %d entries", offset, scope, len(entries))
+	}
+
+	end := min(offset+limit, len(entries))
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Browsing %s (entries %d-%d of %d):\n\n", scope, offset+1, end, len(entries)))
+	for _, e := range entries[offset:end] {
+		sb.WriteString(strings.Repeat("  ", e.Level))
+		switch {
+		case e.IsFolder:
+			sb.WriteString(fmt.Sprintf("- %s (%s): %d resource(s)\n", e.Name, e.URI, e.Count))
+		case e.Description != "":
+			sb.WriteString(fmt.Sprintf("- [%s](%s): %s\n", e.Name, e.URI, e.Description))
+		default:
+			sb.WriteString(fmt.Sprintf("- [%s](%s)\n", e.Name, e.URI))
+		}
+	}
+	if end < len(entries) {
+		sb.WriteString(fmt.Sprintf("\nMore entries available, call again with offset %d to continue.\n", end))
+	}
+	return sb.String()
+}
@@ -17,10 +17,10 @@ import (
 
 // Mock searcher for testing
 type TestMockSearcher struct {
-	MockSearch func(queryStr string, limit *int) ([]search.SearchResult, error)
+	MockSearch func(queryStr string, opts *search.SearchOptions) ([]search.SearchResult, error)
 }
 
-func (m *TestMockSearcher) Search(query string, options *int) ([]search.SearchResult, error) {
+func (m *TestMockSearcher) Search(query string, options *search.SearchOptions) ([]search.SearchResult, error) {
 	if m.MockSearch != nil {
 		return m.MockSearch(query, options)
 	}
@@ -53,7 +53,7 @@ func TestToolRegistration(t *testing.T) {
 
 func TestSearchToolHandler_Success_WithResults(t *testing.T) {
 	mockSearcher := &TestMockSearcher{
-		MockSearch: func(query string, limit *int) ([]search.SearchResult, error) {
+		MockSearch: func(query string, opts *search.SearchOptions) ([]search.SearchResult, error) {
 			assert.Equal(t, "test query", query)
 			return []search.SearchResult{
 				{
@@ -95,7 +95,7 @@ func TestSearchToolHandler_Success_WithResults(t *testing.T) {
 
 func TestSearchToolHandler_Success_NoResults(t *testing.T) {
 	mockSearcher := &TestMockSearcher{
-		MockSearch: func(query string, limit *int) ([]search.SearchResult, error) {
+		MockSearch: func(query string, opts *search.SearchOptions) ([]search.SearchResult, error) {
 			return []search.SearchResult{}, nil
 		},
 	}
@@ -117,10 +117,66 @@ func TestSearchToolHandler_Success_NoResults(t *testing.T) {
 	assert.Contains(t, textContent.Text, "No results found for 'nonexistent'")
 }
 
+func TestSearchToolHandler_Explain(t *testing.T) {
+	mockSearcher := &TestMockSearcher{
+		MockSearch: func(query string, opts *search.SearchOptions) ([]search.SearchResult, error) {
+			require.NotNil(t, opts)
+			assert.True(t, opts.Explain)
+			return []search.SearchResult{
+				{
+					Name:    "Result 1",
+					URI:     "acdc://result1",
+					Snippet: "This is result 1",
+					Score:   1.5,
+					Explanation: &search.Explanation{
+						Score: 1.5,
+						Fields: []search.FieldContribution{
+							{Field: "keywords", Boost: 3, Score: 1.5, Terms: []search.TermMatch{{Term: "auth", Weight: 1.5}}},
+						},
+					},
+				},
+			}, nil
+		},
+	}
+
+	handler := NewSearchToolHandler(mockSearcher)
+	result, _, err := handler(context.Background(), &mcp.CallToolRequest{}, SearchToolArgument{Query: "auth", Explain: true})
+
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok)
+	assert.Contains(t, textContent.Text, "score 1.5000")
+	assert.Contains(t, textContent.Text, "keywords: 1.5000 (boost 3.0) terms: auth=1.5000")
+}
+
+func TestSearchToolHandler_Options(t *testing.T) {
+	mockSearcher := &TestMockSearcher{
+		MockSearch: func(query string, opts *search.SearchOptions) ([]search.SearchResult, error) {
+			require.NotNil(t, opts)
+			require.NotNil(t, opts.Limit)
+			assert.Equal(t, 3, *opts.Limit)
+			assert.Equal(t, "acdc://guides/", opts.URIPrefix)
+			assert.Equal(t, map[string]float64{"name": 5}, opts.Boosts)
+			return nil, nil
+		},
+	}
+
+	handler := NewSearchToolHandler(mockSearcher)
+	args := SearchToolArgument{
+		Query:     "auth",
+		Limit:     3,
+		URIPrefix: "acdc://guides/",
+		Boosts:    map[string]float64{"name": 5},
+	}
+	_, _, err := handler(context.Background(), &mcp.CallToolRequest{}, args)
+	require.NoError(t, err)
+}
+
 func TestSearchToolHandler_Error(t *testing.T) {
 	expectedErr := errors.New("search service error")
 	mockSearcher := &TestMockSearcher{
-		MockSearch: func(query string, limit *int) ([]search.SearchResult, error) {
+		MockSearch: func(query string, opts *search.SearchOptions) ([]search.SearchResult, error) {
 			return nil, expectedErr
 		},
 	}
@@ -175,6 +231,26 @@ func TestReadToolHandler_Success(t *testing.T) {
 	assert.Equal(t, "# Test Content\n\nThis is test content.", textContent.Text)
 }
 
+func TestReadToolHandler_Alias(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "setup.md")
+	require.NoError(t, os.WriteFile(filePath, []byte("---\nname: Setup\ndescription: d\n---\nSetup body"), 0644))
+
+	resourceProvider := resources.NewResourceProvider([]resources.ResourceDefinition{
+		{URI: "acdc://guides/setup", Name: "Setup", MIMEType: "text/markdown", FilePath: filePath, Aliases: []string{"acdc://setup"}},
+	})
+
+	result, _, err := NewReadToolHandler(resourceProvider)(context.Background(), &mcp.CallToolRequest{}, ReadToolArgument{URI: "acdc://setup"})
+	require.NoError(t, err)
+	textContent := result.Content[0].(*mcp.TextContent)
+	assert.Equal(t, "Setup body", textContent.Text)
+	assert.Equal(t, "acdc://guides/setup", textContent.Meta[metaCanonicalURI])
+
+	result, _, err = NewReadManyToolHandler(resourceProvider, 0)(context.Background(), &mcp.CallToolRequest{}, ReadManyToolArgument{URIs: []string{"acdc://setup", "acdc://guides/setup"}})
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	assert.Equal(t, "acdc://guides/setup", result.Content[0].(*mcp.EmbeddedResource).Resource.URI)
+}
+
 func TestReadToolHandler_Error_ResourceNotFound(t *testing.T) {
 	resourceProvider := resources.NewResourceProvider([]resources.ResourceDefinition{})
 
@@ -190,3 +266,125 @@ func TestReadToolHandler_Error_ResourceNotFound(t *testing.T) {
 	assert.Nil(t, result)
 	assert.Nil(t, extra)
 }
+
+func TestReadManyToolHandler(t *testing.T) {
+	tempDir := t.TempDir()
+	var defs []resources.ResourceDefinition
+	for name, body := range map[string]string{"a": "Alpha content", "b": "Beta content", "c": "Gamma content"} {
+		filePath := filepath.Join(tempDir, name+".md")
+		require.NoError(t, os.WriteFile(filePath, []byte("---\nname: "+name+"\ndescription: d\n---\n"+body), 0644))
+		defs = append(defs, resources.ResourceDefinition{Name: name, URI: "acdc://" + name, FilePath: filePath})
+	}
+	resourceProvider := resources.NewResourceProvider(defs)
+	ctx := context.Background()
+
+	t.Run("ReturnsEachResourceInOrder", func(t *testing.T) {
+		handler := NewReadManyToolHandler(resourceProvider, 0)
+		result, _, err := handler(ctx, &mcp.CallToolRequest{}, ReadManyToolArgument{URIs: []string{"acdc://b", "acdc://a", "acdc://b"}})
+		require.NoError(t, err)
+		require.Len(t, result.Content, 2, "duplicate URIs are returned once")
+
+		first := result.Content[0].(*mcp.EmbeddedResource).Resource
+		assert.Equal(t, "acdc://b", first.URI)
+		assert.Equal(t, "text/markdown", first.MIMEType)
+		assert.Equal(t, "Beta content", first.Text)
+		assert.Equal(t, "acdc://a", result.Content[1].(*mcp.EmbeddedResource).Resource.URI)
+	})
+
+	t.Run("ReportsErrorsInline", func(t *testing.T) {
+		handler := NewReadManyToolHandler(resourceProvider, 0)
+		result, _, err := handler(ctx, &mcp.CallToolRequest{}, ReadManyToolArgument{URIs: []string{"acdc://missing", "acdc://a"}})
+		require.NoError(t, err)
+		require.Len(t, result.Content, 2)
+		assert.False(t, result.IsError)
+
+		text := result.Content[0].(*mcp.TextContent).Text
+		assert.Contains(t, text, "Error reading acdc://missing")
+		assert.Equal(t, "Alpha content", result.Content[1].(*mcp.EmbeddedResource).Resource.Text)
+	})
+
+	t.Run("SizeLimit", func(t *testing.T) {
+		handler := NewReadManyToolHandler(resourceProvider, len("Alpha content")+len("Beta content"))
+		result, _, err := handler(ctx, &mcp.CallToolRequest{}, ReadManyToolArgument{URIs: []string{"acdc://a", "acdc://c", "acdc://b"}})
+		require.NoError(t, err)
+		require.Len(t, result.Content, 3)
+
+		assert.Equal(t, "acdc://a", result.Content[0].(*mcp.EmbeddedResource).Resource.URI)
+		assert.Contains(t, result.Content[1].(*mcp.TextContent).Text, "Skipped acdc://c")
+		assert.Equal(t, "acdc://b", result.Content[2].(*mcp.EmbeddedResource).Resource.URI, "smaller resources still fit after a skipped one")
+	})
+
+	t.Run("NoURIs", func(t *testing.T) {
+		handler := NewReadManyToolHandler(resourceProvider, 0)
+		_, _, err := handler(ctx, &mcp.CallToolRequest{}, ReadManyToolArgument{})
+		require.Error(t, err)
+	})
+}
+
+func TestBrowseToolHandler(t *testing.T) {
+	resourceProvider := resources.NewResourceProvider([]resources.ResourceDefinition{
+		{URI: "acdc://readme", Name: "Readme", Description: "Start here"},
+		{URI: "acdc://guides/setup", Name: "Setup", Description: "Install the tools"},
+		{URI: "acdc://guides/api/auth", Name: "Auth"},
+	})
+	handler := NewBrowseToolHandler(resourceProvider)
+	ctx := context.Background()
+
+	browse := func(args BrowseToolArgument) string {
+		result, _, err := handler(ctx, &mcp.CallToolRequest{}, args)
+		require.NoError(t, err)
+		require.Len(t, result.Content, 1)
+		return result.Content[0].(*mcp.TextContent).Text
+	}
+
+	t.Run("Tree", func(t *testing.T) {
+		assert.Equal(t, "Browsing all resources (entries 1-5 of 5):\n\n"+
+			"- guides/ (acdc://guides/): 2 resource(s)\n"+
+			"  - api/ (acdc://guides/api/): 1 resource(s)\n"+
+			"    - [Auth](acdc://guides/api/auth)\n"+
+			"  - [Setup](acdc://guides/setup): Install the tools\n"+
+			"- [Readme](acdc://readme): Start here\n", browse(BrowseToolArgument{}))
+	})
+
+	t.Run("PrefixAndDepth", func(t *testing.T) {
+		text := browse(BrowseToolArgument{URIPrefix: "acdc://guides/", Depth: 1})
+		assert.Contains(t, text, "Browsing resources under 'acdc://guides/' (entries 1-2 of 2)")
+		assert.Contains(t, text, "- api/ (acdc://guides/api/): 1 resource(s)")
+		assert.NotContains(t, text, "Auth")
+	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		text := browse(BrowseToolArgument{Limit: 2})
+		assert.Contains(t, text, "(entries 1-2 of 5)")
+		assert.Contains(t, text, "call again with offset 2")
+
+		text = browse(BrowseToolArgument{Offset: 2, Limit: 2})
+		assert.Contains(t, text, "(entries 3-4 of 5)")
+		assert.Contains(t, text, "[Auth]")
+
+		text = browse(BrowseToolArgument{Offset: 4})
+		assert.Contains(t, text, "(entries 5-5 of 5)")
+		assert.NotContains(t, text, "offset")
+
+		assert.Equal(t, "No entries after offset 9, the listing of all resources has 5 entries", browse(BrowseToolArgument{Offset: 9}))
+	})
+
+	t.Run("NoResources", func(t *testing.T) {
+		assert.Equal(t, "No resources under 'acdc://missing/' found", browse(BrowseToolArgument{URIPrefix: "acdc://missing/"}))
+	})
+
+	t.Run("NegativeArguments", func(t *testing.T) {
+		_, _, err := handler(ctx, &mcp.CallToolRequest{}, BrowseToolArgument{Depth: -1})
+		require.Error(t, err)
+	})
+}
+
+func TestNewToolAnnotations(t *testing.T) {
+	assert.Equal(t, &mcp.ToolAnnotations{ReadOnlyHint: true, IdempotentHint: true}, newToolAnnotations(nil))
+
+	no := false
+	assert.Equal(t,
+		&mcp.ToolAnnotations{Title: "Docs", ReadOnlyHint: false, IdempotentHint: true},
+		newToolAnnotations(&domain.ToolAnnotations{Title: "Docs", ReadOnlyHint: &no}),
+	)
+}
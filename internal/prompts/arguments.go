@@ -0,0 +1,224 @@
+package prompts
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Prompt argument types
+const (
+	ArgTypeString = "string"
+	ArgTypeInt    = "int"
+	ArgTypeBool   = "bool"
+	ArgTypeEnum   = "enum"
+)
+
+// parseArguments parses the arguments field of a prompt's frontmatter.
+// All invalid argument definitions are reported in one error; problems that only
+// disable a feature of an argument, such as an invalid completion source, are
+// returned as warnings.
+func parseArguments(raw interface{}) ([]PromptArgument, []string, error) {
+	args, ok := raw.([]interface{})
+	if !ok {
+		return nil, nil, nil
+	}
+
+	var arguments []PromptArgument
+	var warnings []string
+	var problems []string
+	for _, a := range args {
+		amap, ok := a.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		arg, err := parseArgument(amap)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("argument %q: %v", arg.Name, err))
+			continue
+		}
+		if rawCompletion, ok := amap["completion"]; ok {
+			if arg.Completion, err = parseCompletionSource(rawCompletion); err != nil {
+				warnings = append(warnings, fmt.Sprintf("argument %q: %v", arg.Name, err))
+			}
+		}
+		if arg.Name != "" {
+			arguments = append(arguments, arg)
+		}
+	}
+	if len(problems) > 0 {
+		return nil, warnings, fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return arguments, warnings, nil
+}
+
+// parseArgument parses a single argument definition. The returned argument
+// carries the name even when an error is returned.
+func parseArgument(amap map[string]interface{}) (PromptArgument, error) {
+	arg := PromptArgument{Type: ArgTypeString}
+	arg.Name, _ = amap["name"].(string)
+	arg.Description, _ = amap["description"].(string)
+	required, ok := amap["required"].(bool)
+	if !ok {
+		required = true // default to required
+	}
+	arg.Required = required
+
+	if rawType, ok := amap["type"]; ok {
+		typ, _ := rawType.(string)
+		switch typ {
+		case ArgTypeString, ArgTypeInt, ArgTypeBool, ArgTypeEnum:
+			arg.Type = typ
+		default:
+			return arg, fmt.Errorf("unsupported type %v, must be one of %s, %s, %s or %s", rawType, ArgTypeString, ArgTypeInt, ArgTypeBool, ArgTypeEnum)
+		}
+	}
+
+	if rawEnum, ok := amap["enum"]; ok {
+		list, ok := rawEnum.([]interface{})
+		if !ok || len(list) == 0 {
+			return arg, fmt.Errorf("enum must be a non-empty list")
+		}
+		if _, typed := amap["type"]; typed && arg.Type != ArgTypeEnum {
+			return arg, fmt.Errorf("enum values require type %s", ArgTypeEnum)
+		}
+		arg.Type = ArgTypeEnum
+		for _, v := range list {
+			arg.Enum = append(arg.Enum, fmt.Sprint(v))
+		}
+	}
+	if arg.Type == ArgTypeEnum && len(arg.Enum) == 0 {
+		return arg, fmt.Errorf("type %s requires enum values", ArgTypeEnum)
+	}
+
+	if rawPattern, ok := amap["pattern"]; ok {
+		pattern, ok := rawPattern.(string)
+		if !ok {
+			return arg, fmt.Errorf("pattern must be a string")
+		}
+		if arg.Type != ArgTypeString {
+			return arg, fmt.Errorf("pattern is only supported for type %s", ArgTypeString)
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return arg, fmt.Errorf("invalid pattern: %w", err)
+		}
+		arg.Pattern = re
+	}
+
+	if rawDefault, ok := amap["default"]; ok && rawDefault != nil {
+		value, err := arg.normalize(fmt.Sprint(rawDefault))
+		if err != nil {
+			return arg, fmt.Errorf("invalid default: %w", err)
+		}
+		arg.Default = &value
+	}
+
+	return arg, nil
+}
+
+// normalize validates value against the argument's type and constraints and
+// returns its canonical form
+func (a PromptArgument) normalize(value string) (string, error) {
+	switch a.Type {
+	case ArgTypeInt:
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return "", fmt.Errorf("must be an integer, got %q", value)
+		}
+		return strconv.Itoa(n), nil
+	case ArgTypeBool:
+		b, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return "", fmt.Errorf("must be true or false, got %q", value)
+		}
+		return strconv.FormatBool(b), nil
+	case ArgTypeEnum:
+		for _, e := range a.Enum {
+			if value == e {
+				return value, nil
+			}
+		}
+		return "", fmt.Errorf("must be one of %s, got %q", strings.Join(a.Enum, ", "), value)
+	}
+
+	if a.Pattern != nil && !a.Pattern.MatchString(value) {
+		return "", fmt.Errorf("must match pattern %s, got %q", a.Pattern, value)
+	}
+	return value, nil
+}
+
+// resolveArguments validates values against the argument definitions, fills in
+// defaults and returns the values to render the template with. Every invalid
+// argument is reported in the returned error. Values for undeclared arguments
+// are passed through unchanged.
+func resolveArguments(definitions []PromptArgument, values map[string]string) (map[string]string, error) {
+	resolved := make(map[string]string, len(values))
+	for k, v := range values {
+		resolved[k] = v
+	}
+
+	var problems []string
+	for _, arg := range definitions {
+		value, ok := values[arg.Name]
+		if !ok || value == "" {
+			switch {
+			case arg.Default != nil:
+				resolved[arg.Name] = *arg.Default
+			case arg.Required:
+				problems = append(problems, fmt.Sprintf("missing required argument: %s", arg.Name))
+			}
+			continue
+		}
+
+		normalized, err := arg.normalize(value)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", arg.Name, err))
+			continue
+		}
+		resolved[arg.Name] = normalized
+	}
+
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid prompt arguments: %s", strings.Join(problems, "; "))
+	}
+	return resolved, nil
+}
+
+// describe returns the argument description followed by its type and constraints
+func (a PromptArgument) describe() string {
+	var details []string
+	switch a.Type {
+	case ArgTypeInt, ArgTypeBool:
+		details = append(details, "type: "+a.Type)
+	case ArgTypeEnum:
+		details = append(details, "one of: "+strings.Join(a.Enum, ", "))
+	}
+	if a.Pattern != nil {
+		details = append(details, "pattern: "+a.Pattern.String())
+	}
+	if a.Default != nil {
+		details = append(details, "default: "+*a.Default)
+	}
+
+	if len(details) == 0 {
+		return a.Description
+	}
+	suffix := "(" + strings.Join(details, "; ") + ")"
+	if a.Description == "" {
+		return suffix
+	}
+	return a.Description + " " + suffix
+}
+
+// typeCompletions returns the values implied by the argument's type
+func (a PromptArgument) typeCompletions() []string {
+	switch a.Type {
+	case ArgTypeEnum:
+		return a.Enum
+	case ArgTypeBool:
+		return []string{"true", "false"}
+	}
+	return nil
+}
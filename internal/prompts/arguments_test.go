@@ -0,0 +1,218 @@
+package prompts
+
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseArguments(t *testing.T) {
+	raw := []interface{}{
+		map[string]interface{}{"name": "topic", "description": "Topic"},
+		map[string]interface{}{"name": "count", "type": "int", "default": 3, "required": false},
+		map[string]interface{}{"name": "verbose", "type": "bool", "default": true},
+		map[string]interface{}{"name": "level", "enum": []interface{}{"low", "high"}},
+		map[string]interface{}{"name": "ticket", "pattern": `^[A-Z]+-\d+$`},
+	}
+
+	args, warnings, err := parseArguments(raw)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	require.Len(t, args, 5)
+
+	assert.Equal(t, ArgTypeString, args[0].Type)
+	assert.True(t, args[0].Required)
+	assert.Nil(t, args[0].Default)
+
+	assert.Equal(t, ArgTypeInt, args[1].Type)
+	require.NotNil(t, args[1].Default)
+	assert.Equal(t, "3", *args[1].Default)
+
+	assert.Equal(t, ArgTypeBool, args[2].Type)
+	assert.Equal(t, "true", *args[2].Default)
+
+	assert.Equal(t, ArgTypeEnum, args[3].Type, "enum values imply the enum type")
+	assert.Equal(t, []string{"low", "high"}, args[3].Enum)
+
+	require.NotNil(t, args[4].Pattern)
+	assert.True(t, args[4].Pattern.MatchString("OPS-12"))
+}
+
+func TestParseArguments_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		argument map[string]interface{}
+		errMsg   string
+	}{
+		{"UnknownType", map[string]interface{}{"name": "a", "type": "float"}, "unsupported type float"},
+		{"EnumTypeWithoutValues", map[string]interface{}{"name": "a", "type": "enum"}, "requires enum values"},
+		{"EmptyEnum", map[string]interface{}{"name": "a", "enum": []interface{}{}}, "non-empty list"},
+		{"EnumWithOtherType", map[string]interface{}{"name": "a", "type": "int", "enum": []interface{}{1, 2}}, "require type enum"},
+		{"PatternOnInt", map[string]interface{}{"name": "a", "type": "int", "pattern": `\d+`}, "only supported for type string"},
+		{"InvalidPattern", map[string]interface{}{"name": "a", "pattern": "("}, "invalid pattern"},
+		{"InvalidIntDefault", map[string]interface{}{"name": "a", "type": "int", "default": "many"}, "invalid default"},
+		{"DefaultNotInEnum", map[string]interface{}{"name": "a", "enum": []interface{}{"x"}, "default": "y"}, "invalid default"},
+		{"DefaultNotMatchingPattern", map[string]interface{}{"name": "a", "pattern": `^\d+$`, "default": "abc"}, "invalid default"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := parseArguments([]interface{}{tt.argument})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), `argument "a"`)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestParseArguments_ReportsAllInvalidArguments(t *testing.T) {
+	_, _, err := parseArguments([]interface{}{
+		map[string]interface{}{"name": "a", "type": "float"},
+		map[string]interface{}{"name": "ok"},
+		map[string]interface{}{"name": "b", "pattern": "("},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `argument "a": unsupported type`)
+	assert.Contains(t, err.Error(), `argument "b": invalid pattern`)
+	assert.NotContains(t, err.Error(), `"ok"`)
+}
+
+func TestParseArguments_InvalidCompletionIsWarning(t *testing.T) {
+	args, warnings, err := parseArguments([]interface{}{
+		map[string]interface{}{"name": "a", "completion": "bogus"},
+	})
+	require.NoError(t, err)
+	require.Len(t, args, 1)
+	assert.Nil(t, args[0].Completion)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], `argument "a"`)
+}
+
+func TestResolveArguments(t *testing.T) {
+	args, _, err := parseArguments([]interface{}{
+		map[string]interface{}{"name": "topic"},
+		map[string]interface{}{"name": "count", "type": "int", "default": 3},
+		map[string]interface{}{"name": "verbose", "type": "bool", "required": false},
+		map[string]interface{}{"name": "level", "enum": []interface{}{"low", "high"}, "default": "low"},
+	})
+	require.NoError(t, err)
+
+	values, err := resolveArguments(args, map[string]string{"topic": "search", "count": " 07 ", "verbose": "1", "extra": "kept"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"topic":   "search",
+		"count":   "7",
+		"verbose": "true",
+		"level":   "low",
+		"extra":   "kept",
+	}, values)
+
+	values, err = resolveArguments(args, map[string]string{"topic": "search"})
+	require.NoError(t, err)
+	assert.Equal(t, "3", values["count"])
+	_, ok := values["verbose"]
+	assert.False(t, ok, "optional arguments without a default are left unset")
+}
+
+func TestResolveArguments_ReportsEveryProblem(t *testing.T) {
+	args, _, err := parseArguments([]interface{}{
+		map[string]interface{}{"name": "topic"},
+		map[string]interface{}{"name": "count", "type": "int"},
+		map[string]interface{}{"name": "level", "enum": []interface{}{"low", "high"}},
+		map[string]interface{}{"name": "ticket", "pattern": `^[A-Z]+-\d+$`},
+	})
+	require.NoError(t, err)
+
+	_, err = resolveArguments(args, map[string]string{"count": "many", "level": "medium", "ticket": "ops12"})
+	require.Error(t, err)
+	msg := err.Error()
+	assert.Contains(t, msg, "invalid prompt arguments")
+	assert.Contains(t, msg, "missing required argument: topic")
+	assert.Contains(t, msg, `count: must be an integer, got "many"`)
+	assert.Contains(t, msg, `level: must be one of low, high, got "medium"`)
+	assert.Contains(t, msg, "ticket: must match pattern")
+}
+
+func TestPromptArgument_Describe(t *testing.T) {
+	def := "2"
+	tests := []struct {
+		name     string
+		arg      PromptArgument
+		expected string
+	}{
+		{"PlainString", PromptArgument{Description: "Topic", Type: ArgTypeString}, "Topic"},
+		{"Int", PromptArgument{Description: "Count", Type: ArgTypeInt, Default: &def}, "Count (type: int; default: 2)"},
+		{"Enum", PromptArgument{Description: "Level", Type: ArgTypeEnum, Enum: []string{"low", "high"}}, "Level (one of: low, high)"},
+		{"NoDescription", PromptArgument{Type: ArgTypeBool}, "(type: bool)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.arg.describe())
+		})
+	}
+}
+
+func TestDiscoverPrompts_TypedArguments(t *testing.T) {
+	cp := writePromptFiles(t, map[string]string{
+		"report.md": `---
+name: report
+description: Report
+arguments:
+  - name: area
+    description: Area
+  - name: limit
+    description: Max items
+    type: int
+    default: 5
+  - name: format
+    description: Output format
+    enum: [markdown, json]
+    default: markdown
+---
+Top {{.limit}} in {{.area}} as {{.format}}`,
+		"broken.md": `---
+name: broken
+description: Broken
+arguments:
+  - name: limit
+    type: int
+    default: lots
+---
+{{.limit}}`,
+	})
+
+	defs, issues, err := DiscoverPromptsWithIssues(cp)
+	require.NoError(t, err)
+	require.Len(t, defs, 1)
+	require.Len(t, issues, 1)
+	assert.Equal(t, domain.RuleFrontmatter, issues[0].Rule)
+	assert.Contains(t, issues[0].Message, `argument "limit": invalid default`)
+
+	provider := NewPromptProvider(defs, cp)
+
+	listed := provider.ListPrompts()
+	require.Len(t, listed, 1)
+	assert.Equal(t, []*mcp.PromptArgument{
+		{Name: "area", Description: "Area", Required: true},
+		{Name: "limit", Description: "Max items (type: int; default: 5)"},
+		{Name: "format", Description: "Output format (one of: markdown, json; default: markdown)"},
+	}, listed[0].Arguments)
+
+	messages, err := provider.GetPrompt("report", map[string]string{"area": "billing"})
+	require.NoError(t, err)
+	assert.Equal(t, "Top 5 in billing as markdown", messages[0].Content.(*mcp.TextContent).Text)
+
+	_, err = provider.GetPrompt("report", map[string]string{"limit": "x", "format": "xml"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing required argument: area")
+	assert.Contains(t, err.Error(), "limit: must be an integer")
+	assert.Contains(t, err.Error(), "format: must be one of markdown, json")
+
+	values, err := provider.Complete("report", "format", "j", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"json"}, values)
+}
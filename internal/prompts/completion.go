@@ -0,0 +1,224 @@
+package prompts
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sha1n/mcp-acdc-server/internal/search"
+)
+
+// CompletionSource declares where completion values for a prompt argument come from.
+// Exactly one of Values, URIPrefix or Search is expected to be set; Search may
+// be combined with URIPrefix to scope the search. URIPrefix and Query are
+// templates rendered with the arguments the client has already resolved, so
+// that completions can depend on them, e.g. acdc://{{.service}}/runbooks/.
+type CompletionSource struct {
+	Values    []string // Static list of allowed values
+	URIPrefix string   // Resource URIs under this prefix
+	Search    bool     // Resource URIs matching a search for the typed value
+	Query     string   // Search query, the typed value if empty
+}
+
+// Resources gives prompts access to the resources served alongside them,
+// for completion values and embedded resources
+type Resources interface {
+	ListResources() []mcp.Resource
+	ReadResource(uri string) (string, error)
+}
+
+// Option configures a PromptProvider.
+type Option func(*PromptProvider)
+
+// WithResources sets the resources used to complete uri_prefix arguments and
+// to embed resources in prompt messages.
+func WithResources(resources Resources) Option {
+	return func(p *PromptProvider) {
+		p.resources = resources
+	}
+}
+
+// WithSearcher sets the search service used to complete search arguments.
+func WithSearcher(searcher search.Searcher) Option {
+	return func(p *PromptProvider) {
+		p.searcher = searcher
+	}
+}
+
+// Complete returns completion values for a prompt argument given the
+// partially typed value and the values of the arguments the client has already
+// resolved. Enum and bool arguments without a completion source complete from
+// their type; other arguments without one have no values.
+func (p *PromptProvider) Complete(name, argument, value string, resolved map[string]string) ([]string, error) {
+	defn, ok := p.nameMap[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown prompt: %s", name)
+	}
+
+	var source *CompletionSource
+	for _, a := range defn.Arguments {
+		if a.Name == argument {
+			source = a.Completion
+			// Typed arguments without an explicit source complete from their type
+			if source == nil && len(a.typeCompletions()) > 0 {
+				source = &CompletionSource{Values: a.typeCompletions()}
+			}
+			break
+		}
+	}
+	if source == nil {
+		return []string{}, nil
+	}
+
+	if len(source.Values) > 0 {
+		return filterByPrefix(source.Values, value), nil
+	}
+
+	// The argument being completed has the typed value
+	args := make(map[string]string, len(resolved)+1)
+	for k, v := range resolved {
+		args[k] = v
+	}
+	args[argument] = value
+	uriPrefix, err := renderCompletionTemplate("uri_prefix", source.URIPrefix, args)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case source.Search:
+		query := value
+		if source.Query != "" {
+			if query, err = renderCompletionTemplate("query", source.Query, args); err != nil {
+				return nil, err
+			}
+		}
+		return p.completeFromSearch(uriPrefix, query)
+	case uriPrefix != "":
+		return p.completeFromResources(uriPrefix, value), nil
+	}
+	return []string{}, nil
+}
+
+// renderCompletionTemplate renders a template of a completion source with
+// the given arguments. Arguments that are not resolved render empty.
+func renderCompletionTemplate(name, text string, args map[string]string) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+	tmpl, err := template.New(name).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid completion %s: %w", name, err)
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, args); err != nil {
+		return "", fmt.Errorf("failed to render completion %s: %w", name, err)
+	}
+	return b.String(), nil
+}
+
+func (p *PromptProvider) completeFromResources(uriPrefix, value string) []string {
+	values := []string{}
+	if p.resources == nil {
+		return values
+	}
+
+	lowerValue := strings.ToLower(value)
+	for _, res := range p.resources.ListResources() {
+		if !strings.HasPrefix(res.URI, uriPrefix) {
+			continue
+		}
+		// Match either the full URI or the part after the prefix
+		lowerURI := strings.ToLower(res.URI)
+		if strings.HasPrefix(lowerURI, lowerValue) || strings.HasPrefix(strings.TrimPrefix(lowerURI, strings.ToLower(uriPrefix)), lowerValue) {
+			values = append(values, res.URI)
+		}
+	}
+	return values
+}
+
+func (p *PromptProvider) completeFromSearch(uriPrefix, value string) ([]string, error) {
+	values := []string{}
+	if p.searcher == nil || strings.TrimSpace(value) == "" {
+		return values, nil
+	}
+
+	results, err := p.searcher.Search(value, &search.SearchOptions{URIPrefix: uriPrefix})
+	if err != nil {
+		return nil, fmt.Errorf("search failed: %w", err)
+	}
+	for _, r := range results {
+		values = append(values, r.URI)
+	}
+	return values, nil
+}
+
+// filterByPrefix returns the values starting with prefix, ignoring case
+func filterByPrefix(values []string, prefix string) []string {
+	filtered := []string{}
+	lowerPrefix := strings.ToLower(prefix)
+	for _, v := range values {
+		if strings.HasPrefix(strings.ToLower(v), lowerPrefix) {
+			filtered = append(filtered, v)
+		}
+	}
+	return filtered
+}
+
+// parseCompletionSource parses the completion field of a prompt argument
+func parseCompletionSource(raw interface{}) (*CompletionSource, error) {
+	m, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("completion must be a mapping")
+	}
+
+	source := &CompletionSource{}
+	if rawValues, ok := m["values"]; ok {
+		list, ok := rawValues.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("completion values must be a list")
+		}
+		for _, v := range list {
+			source.Values = append(source.Values, fmt.Sprint(v))
+		}
+	}
+	if rawPrefix, ok := m["uri_prefix"]; ok {
+		prefix, ok := rawPrefix.(string)
+		if !ok {
+			return nil, fmt.Errorf("completion uri_prefix must be a string")
+		}
+		if _, err := template.New("uri_prefix").Parse(prefix); err != nil {
+			return nil, fmt.Errorf("completion uri_prefix is not a valid template: %w", err)
+		}
+		source.URIPrefix = prefix
+	}
+	if rawSearch, ok := m["search"]; ok {
+		enabled, ok := rawSearch.(bool)
+		if !ok {
+			return nil, fmt.Errorf("completion search must be a boolean")
+		}
+		source.Search = enabled
+	}
+	if rawQuery, ok := m["query"]; ok {
+		query, ok := rawQuery.(string)
+		if !ok {
+			return nil, fmt.Errorf("completion query must be a string")
+		}
+		if _, err := template.New("query").Parse(query); err != nil {
+			return nil, fmt.Errorf("completion query is not a valid template: %w", err)
+		}
+		source.Query = query
+	}
+
+	if len(source.Values) > 0 && (source.URIPrefix != "" || source.Search) {
+		return nil, fmt.Errorf("completion values cannot be combined with uri_prefix or search")
+	}
+	if source.Query != "" && !source.Search {
+		return nil, fmt.Errorf("completion query requires search")
+	}
+	if len(source.Values) == 0 && source.URIPrefix == "" && !source.Search {
+		return nil, fmt.Errorf("completion must declare values, uri_prefix or search")
+	}
+	return source, nil
+}
@@ -0,0 +1,206 @@
+package prompts
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sha1n/mcp-acdc-server/internal/content"
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+	"github.com/sha1n/mcp-acdc-server/internal/search"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticResources []mcp.Resource
+
+func (r staticResources) ListResources() []mcp.Resource { return r }
+
+func (r staticResources) ReadResource(uri string) (string, error) {
+	for _, res := range r {
+		if res.URI == uri {
+			return "Content of " + uri, nil
+		}
+	}
+	return "", errors.New("unknown resource: " + uri)
+}
+
+type stubSearcher struct {
+	results []search.SearchResult
+	err     error
+	query   string
+	opts    *search.SearchOptions
+}
+
+func (s *stubSearcher) Search(query string, opts *search.SearchOptions) ([]search.SearchResult, error) {
+	s.query = query
+	s.opts = opts
+	return s.results, s.err
+}
+
+func (s *stubSearcher) Index(ctx context.Context, docs <-chan domain.Document) error { return nil }
+
+func (s *stubSearcher) Close() {}
+
+func TestPromptProvider_Complete(t *testing.T) {
+	searcher := &stubSearcher{results: []search.SearchResult{{URI: "acdc://guides/deploy"}}}
+	provider := NewPromptProvider([]PromptDefinition{{
+		Name: "check-config",
+		Arguments: []PromptArgument{
+			{Name: "setting", Completion: &CompletionSource{Values: []string{"timeout", "Threads", "retries"}}},
+			{Name: "doc", Completion: &CompletionSource{URIPrefix: "acdc://guides/"}},
+			{Name: "topic", Completion: &CompletionSource{Search: true, URIPrefix: "acdc://guides/"}},
+			{Name: "free"},
+			{Name: "service"},
+			{Name: "runbook", Completion: &CompletionSource{URIPrefix: "acdc://runbooks/{{.service}}"}},
+			{Name: "related", Completion: &CompletionSource{Search: true, URIPrefix: "acdc://{{.area}}/", Query: "{{.related}} {{.service}}"}},
+		},
+	}}, nil,
+		WithResources(staticResources{
+			{URI: "acdc://guides/deploy"},
+			{URI: "acdc://guides/debug"},
+			{URI: "acdc://runbooks/db"},
+			{URI: "acdc://runbooks/db-failover"},
+			{URI: "acdc://runbooks/web"},
+		}),
+		WithSearcher(searcher),
+	)
+
+	t.Run("Values", func(t *testing.T) {
+		values, err := provider.Complete("check-config", "setting", "t", nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"timeout", "Threads"}, values)
+	})
+
+	t.Run("URIPrefix", func(t *testing.T) {
+		values, err := provider.Complete("check-config", "doc", "", nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"acdc://guides/deploy", "acdc://guides/debug"}, values)
+
+		values, err = provider.Complete("check-config", "doc", "dep", nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"acdc://guides/deploy"}, values)
+
+		values, err = provider.Complete("check-config", "doc", "acdc://guides/deb", nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"acdc://guides/debug"}, values)
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		values, err := provider.Complete("check-config", "topic", "deploy", nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"acdc://guides/deploy"}, values)
+		assert.Equal(t, "deploy", searcher.query)
+		assert.Equal(t, "acdc://guides/", searcher.opts.URIPrefix)
+
+		values, err = provider.Complete("check-config", "topic", " ", nil)
+		require.NoError(t, err)
+		assert.Empty(t, values)
+	})
+
+	t.Run("ResolvedArguments", func(t *testing.T) {
+		values, err := provider.Complete("check-config", "runbook", "", map[string]string{"service": "db"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"acdc://runbooks/db", "acdc://runbooks/db-failover"}, values)
+
+		values, err = provider.Complete("check-config", "runbook", "", nil)
+		require.NoError(t, err)
+		assert.Len(t, values, 3)
+
+		_, err = provider.Complete("check-config", "related", "rollback", map[string]string{"service": "db", "area": "guides"})
+		require.NoError(t, err)
+		assert.Equal(t, "rollback db", searcher.query)
+		assert.Equal(t, "acdc://guides/", searcher.opts.URIPrefix)
+	})
+
+	t.Run("SearchError", func(t *testing.T) {
+		failing := NewPromptProvider(provider.definitions, nil, WithSearcher(&stubSearcher{err: errors.New("boom")}))
+		_, err := failing.Complete("check-config", "topic", "x", nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("NoSource", func(t *testing.T) {
+		values, err := provider.Complete("check-config", "free", "x", nil)
+		require.NoError(t, err)
+		assert.Empty(t, values)
+
+		values, err = provider.Complete("check-config", "unknown-arg", "x", nil)
+		require.NoError(t, err)
+		assert.Empty(t, values)
+	})
+
+	t.Run("UnknownPrompt", func(t *testing.T) {
+		_, err := provider.Complete("missing", "setting", "", nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestParseCompletionSource(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     interface{}
+		want    *CompletionSource
+		wantErr bool
+	}{
+		{name: "Values", raw: map[string]interface{}{"values": []interface{}{"a", 1}}, want: &CompletionSource{Values: []string{"a", "1"}}},
+		{name: "URIPrefix", raw: map[string]interface{}{"uri_prefix": "acdc://x/"}, want: &CompletionSource{URIPrefix: "acdc://x/"}},
+		{name: "Search", raw: map[string]interface{}{"search": true}, want: &CompletionSource{Search: true}},
+		{name: "Query", raw: map[string]interface{}{"search": true, "query": "{{.topic}} {{.service}}"}, want: &CompletionSource{Search: true, Query: "{{.topic}} {{.service}}"}},
+		{name: "NotMapping", raw: "values", wantErr: true},
+		{name: "Empty", raw: map[string]interface{}{}, wantErr: true},
+		{name: "ValuesNotList", raw: map[string]interface{}{"values": "a"}, wantErr: true},
+		{name: "PrefixNotString", raw: map[string]interface{}{"uri_prefix": 1}, wantErr: true},
+		{name: "SearchNotBool", raw: map[string]interface{}{"search": "yes"}, wantErr: true},
+		{name: "QueryWithoutSearch", raw: map[string]interface{}{"uri_prefix": "acdc://x/", "query": "x"}, wantErr: true},
+		{name: "InvalidPrefixTemplate", raw: map[string]interface{}{"uri_prefix": "acdc://{{.x/"}, wantErr: true},
+		{name: "ValuesAndSearch", raw: map[string]interface{}{"values": []interface{}{"a"}, "search": true}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCompletionSource(tt.raw)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDiscoverPromptsWithIssues_Completion(t *testing.T) {
+	tempDir := t.TempDir()
+	promptsDir := filepath.Join(tempDir, "mcp-prompts")
+	_ = os.MkdirAll(promptsDir, 0755)
+	mdContent := `---
+name: check-config
+description: Check a setting
+arguments:
+  - name: setting
+    description: Setting name
+    completion:
+      values: [timeout, retries]
+  - name: broken
+    description: Broken completion
+    required: false
+    completion:
+      values: timeout
+---
+Check {{.setting}}`
+	require.NoError(t, os.WriteFile(filepath.Join(promptsDir, "check.md"), []byte(mdContent), 0644))
+
+	defs, issues, err := DiscoverPromptsWithIssues(content.NewContentProvider(tempDir))
+	require.NoError(t, err)
+	require.Len(t, defs, 1)
+	require.Len(t, defs[0].Arguments, 2)
+	assert.Equal(t, []string{"timeout", "retries"}, defs[0].Arguments[0].Completion.Values)
+	assert.Nil(t, defs[0].Arguments[1].Completion)
+
+	require.Len(t, issues, 1)
+	assert.Equal(t, domain.SeverityWarning, issues[0].Severity)
+	assert.Equal(t, domain.RuleFrontmatter, issues[0].Rule)
+	assert.Contains(t, issues[0].Message, `argument "broken"`)
+}
@@ -1,6 +1,7 @@
 package prompts
 
 import (
+	"regexp"
 	"text/template"
 )
 
@@ -11,6 +12,7 @@ type PromptDefinition struct {
 	Arguments   []PromptArgument
 	FilePath    string
 	Template    *template.Template
+	segments    []promptSegment // Parts of the body split on markers, see parseSegments
 }
 
 // PromptArgument definition of an MCP prompt argument
@@ -18,4 +20,9 @@ type PromptArgument struct {
 	Name        string
 	Description string
 	Required    bool
+	Type        string            // One of the ArgType constants, ArgTypeString if empty
+	Default     *string           // Optional default, already normalized for Type
+	Enum        []string          // Allowed values for ArgTypeEnum
+	Pattern     *regexp.Regexp    // Optional pattern for ArgTypeString
+	Completion  *CompletionSource // Optional source of completion values
 }
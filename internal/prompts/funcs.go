@@ -0,0 +1,83 @@
+package prompts
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/sha1n/mcp-acdc-server/internal/content"
+	"github.com/sha1n/mcp-acdc-server/internal/search"
+)
+
+// NewFuncMap returns the functions available in prompt and tool templates. The
+// content functions read from resources and searcher, and fail if they are not set.
+func NewFuncMap(resources Resources, searcher search.Searcher) template.FuncMap {
+	readResource := func(uri string) (string, error) {
+		if resources == nil {
+			return "", fmt.Errorf("cannot read resource %s: no resources available", uri)
+		}
+		return resources.ReadResource(uri)
+	}
+
+	return template.FuncMap{
+		// Content
+		"resource": readResource,
+		"section": func(uri, heading string) (string, error) {
+			text, err := readResource(uri)
+			if err != nil {
+				return "", err
+			}
+			section, ok := content.ExtractSection(text, heading)
+			if !ok {
+				return "", fmt.Errorf("section %q not found in %s", heading, uri)
+			}
+			return section, nil
+		},
+		"search": func(query string, limit int) ([]search.SearchResult, error) {
+			if searcher == nil {
+				return nil, fmt.Errorf("cannot search for %q: search is not available", query)
+			}
+			opts := &search.SearchOptions{}
+			if limit > 0 {
+				opts.Limit = &limit
+			}
+			return searcher.Search(query, opts)
+		},
+
+		// Defaults
+		"default": func(def, value string) string {
+			if strings.TrimSpace(value) == "" {
+				return def
+			}
+			return value
+		},
+		"coalesce": func(values ...string) string {
+			for _, v := range values {
+				if strings.TrimSpace(v) != "" {
+					return v
+				}
+			}
+			return ""
+		},
+		"empty": func(value string) bool {
+			return strings.TrimSpace(value) == ""
+		},
+
+		// Strings
+		"lower":     strings.ToLower,
+		"upper":     strings.ToUpper,
+		"trim":      strings.TrimSpace,
+		"contains":  func(substr, s string) bool { return strings.Contains(s, substr) },
+		"hasPrefix": func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+		"hasSuffix": func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+		"replace":   func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+		"split":     func(sep, s string) []string { return strings.Split(s, sep) },
+		"join":      func(sep string, elems []string) string { return strings.Join(elems, sep) },
+		"quote":     strconv.Quote,
+		"indent": func(spaces int, s string) string {
+			pad := strings.Repeat(" ", spaces)
+			return pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+		},
+	}
+}
@@ -0,0 +1,117 @@
+package prompts
+
+import (
+	"errors"
+	"testing"
+	"text/template"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sha1n/mcp-acdc-server/internal/search"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type markdownResources map[string]string
+
+func (r markdownResources) ListResources() []mcp.Resource { return nil }
+
+func (r markdownResources) ReadResource(uri string) (string, error) {
+	if text, ok := r[uri]; ok {
+		return text, nil
+	}
+	return "", errors.New("unknown resource: " + uri)
+}
+
+func renderWithFuncs(t *testing.T, body string, args map[string]string, opts ...Option) (string, error) {
+	t.Helper()
+	tmpl, err := template.New("p").Option("missingkey=zero").Funcs(NewFuncMap(nil, nil)).Parse(body)
+	require.NoError(t, err)
+	p := NewPromptProvider([]PromptDefinition{{Name: "p", Template: tmpl}}, nil, opts...)
+
+	messages, err := p.GetPrompt("p", args)
+	if err != nil {
+		return "", err
+	}
+	return messages[0].Content.(*mcp.TextContent).Text, nil
+}
+
+func TestTemplateFuncs_Content(t *testing.T) {
+	resources := WithResources(markdownResources{
+		"acdc://standards/go": "# Go\n\nIntro\n\n## Errors\n\nWrap errors.\n\n## Naming\n\nShort names.",
+	})
+	searcher := &stubSearcher{results: []search.SearchResult{
+		{URI: "acdc://a", Name: "A"},
+		{URI: "acdc://b", Name: "B"},
+	}}
+
+	t.Run("Resource", func(t *testing.T) {
+		got, err := renderWithFuncs(t, `{{resource "acdc://standards/go"}}`, nil, resources)
+		require.NoError(t, err)
+		assert.Contains(t, got, "Wrap errors.")
+	})
+
+	t.Run("Section", func(t *testing.T) {
+		got, err := renderWithFuncs(t, `{{section "acdc://standards/go" "errors"}}`, nil, resources)
+		require.NoError(t, err)
+		assert.Equal(t, "## Errors\n\nWrap errors.", got)
+	})
+
+	t.Run("SectionNotFound", func(t *testing.T) {
+		_, err := renderWithFuncs(t, `{{section "acdc://standards/go" "Testing"}}`, nil, resources)
+		assert.ErrorContains(t, err, `section "Testing" not found`)
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		got, err := renderWithFuncs(t, `{{range search .topic 2}}- {{.Name}} ({{.URI}})
+{{end}}`, map[string]string{"topic": "errors"}, WithSearcher(searcher))
+		require.NoError(t, err)
+		assert.Equal(t, "- A (acdc://a)\n- B (acdc://b)\n", got)
+		assert.Equal(t, "errors", searcher.query)
+		assert.Equal(t, 2, *searcher.opts.Limit)
+
+		_, err = renderWithFuncs(t, `{{search .topic -5}}`, map[string]string{"topic": "errors"}, WithSearcher(searcher))
+		require.NoError(t, err)
+		assert.Nil(t, searcher.opts.Limit, "non-positive limits fall back to the configured maximum")
+	})
+
+	t.Run("Unavailable", func(t *testing.T) {
+		_, err := renderWithFuncs(t, `{{resource "acdc://standards/go"}}`, nil)
+		assert.ErrorContains(t, err, "no resources available")
+		_, err = renderWithFuncs(t, `{{search "x" 1}}`, nil)
+		assert.ErrorContains(t, err, "search is not available")
+	})
+
+	t.Run("UnknownResource", func(t *testing.T) {
+		_, err := renderWithFuncs(t, `{{resource "acdc://missing"}}`, nil, resources)
+		assert.Error(t, err)
+	})
+}
+
+func TestTemplateFuncs_Strings(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		args map[string]string
+		want string
+	}{
+		{name: "DefaultMissing", body: `{{.lang | default "go"}}`, want: "go"},
+		{name: "DefaultSet", body: `{{.lang | default "go"}}`, args: map[string]string{"lang": "rust"}, want: "rust"},
+		{name: "Coalesce", body: `{{coalesce .a .b "c"}}`, args: map[string]string{"b": "b"}, want: "b"},
+		{name: "Empty", body: `{{if empty .a}}none{{end}}`, want: "none"},
+		{name: "Case", body: `{{upper "a"}}{{lower "B"}}`, want: "Ab"},
+		{name: "Trim", body: `[{{trim "  x  "}}]`, want: "[x]"},
+		{name: "Contains", body: `{{if contains "ar" .s}}yes{{end}}`, args: map[string]string{"s": "bar"}, want: "yes"},
+		{name: "Prefix", body: `{{if hasPrefix "ba" .s}}p{{end}}{{if hasSuffix "ar" .s}}s{{end}}`, args: map[string]string{"s": "bar"}, want: "ps"},
+		{name: "Replace", body: `{{replace "-" "_" "a-b"}}`, want: "a_b"},
+		{name: "SplitJoin", body: `{{split "," "a,b" | join " + "}}`, want: "a + b"},
+		{name: "Quote", body: `{{quote "x"}}`, want: `"x"`},
+		{name: "Indent", body: `{{indent 2 "a\nb"}}`, want: "  a\n  b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renderWithFuncs(t, tt.body, tt.args)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
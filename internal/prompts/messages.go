@@ -0,0 +1,174 @@
+package prompts
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Marker kinds recognised in prompt bodies
+const (
+	markerMessage  = "message"
+	markerResource = "resource"
+)
+
+// Message roles
+const (
+	roleUser      = "user"
+	roleAssistant = "assistant"
+)
+
+// markerPattern matches a marker on its own line, e.g. <!-- message: assistant -->
+// or <!-- resource: acdc://guides/style -->
+var markerPattern = regexp.MustCompile(`(?m)^[ \t]*<!--[ \t]*(message|resource):[ \t]*(.*?)[ \t]*-->[ \t]*\r?$`)
+
+// promptSegment is a part of a prompt body: the text between markers or the
+// value of a marker, each with its own template
+type promptSegment struct {
+	kind string // markerMessage, markerResource, or empty for text
+	name string // Name of the template of the text or marker value
+}
+
+// parseSegments splits a prompt body on its marker lines and parses every
+// text and marker value as a template associated with tmpl. The message
+// structure is fixed by the body: markers in argument values or in content
+// returned by template functions are plain text. A body without markers is
+// the single text segment tmpl.
+func parseSegments(tmpl *template.Template, body string) ([]promptSegment, error) {
+	matches := markerPattern.FindAllStringSubmatchIndex(body, -1)
+	if len(matches) == 0 {
+		return []promptSegment{{name: tmpl.Name()}}, nil
+	}
+
+	var segments []promptSegment
+	add := func(kind, text string) error {
+		name := fmt.Sprintf("%s#%d", tmpl.Name(), len(segments))
+		if _, err := tmpl.New(name).Parse(text); err != nil {
+			return fmt.Errorf("markers must not be inside template actions: %w", err)
+		}
+		segments = append(segments, promptSegment{kind: kind, name: name})
+		return nil
+	}
+
+	pos := 0
+	for _, m := range matches {
+		if err := add("", body[pos:m[0]]); err != nil {
+			return nil, err
+		}
+		if err := add(body[m[2]:m[3]], body[m[4]:m[5]]); err != nil {
+			return nil, err
+		}
+		pos = m[1]
+	}
+	if err := add("", body[pos:]); err != nil {
+		return nil, err
+	}
+	return segments, nil
+}
+
+// buildMessages renders the segments of a prompt into messages. A prompt
+// without markers is returned unchanged as a single user message.
+func (p *PromptProvider) buildMessages(tmpl *template.Template, segments []promptSegment, values map[string]string) ([]*mcp.PromptMessage, error) {
+	render := func(name string) (string, error) {
+		var buf bytes.Buffer
+		if err := tmpl.ExecuteTemplate(&buf, name, values); err != nil {
+			return "", fmt.Errorf("failed to execute prompt template: %w", err)
+		}
+		return buf.String(), nil
+	}
+
+	if len(segments) == 1 && segments[0].kind == "" {
+		text, err := render(segments[0].name)
+		if err != nil {
+			return nil, err
+		}
+		return []*mcp.PromptMessage{{Role: roleUser, Content: &mcp.TextContent{Text: text}}}, nil
+	}
+
+	var messages []*mcp.PromptMessage
+	role := roleUser
+	for _, segment := range segments {
+		rendered, err := render(segment.name)
+		if err != nil {
+			return nil, err
+		}
+		value := strings.TrimSpace(rendered)
+
+		switch segment.kind {
+		case markerMessage:
+			if err := validateRole(value); err != nil {
+				return nil, err
+			}
+			role = value
+		case markerResource:
+			embedded, err := p.embedResource(value)
+			if err != nil {
+				return nil, err
+			}
+			messages = append(messages, &mcp.PromptMessage{Role: mcp.Role(role), Content: embedded})
+		default:
+			if value != "" {
+				messages = append(messages, &mcp.PromptMessage{Role: mcp.Role(role), Content: &mcp.TextContent{Text: value}})
+			}
+		}
+	}
+	return messages, nil
+}
+
+// embedResource reads a resource by URI into embedded resource content
+func (p *PromptProvider) embedResource(uri string) (*mcp.EmbeddedResource, error) {
+	if p.resources == nil {
+		return nil, fmt.Errorf("cannot embed resource %s: no resources available", uri)
+	}
+
+	text, err := p.resources.ReadResource(uri)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed resource: %w", err)
+	}
+
+	mimeType := "text/markdown"
+	for _, res := range p.resources.ListResources() {
+		if res.URI == uri && res.MIMEType != "" {
+			mimeType = res.MIMEType
+			break
+		}
+	}
+
+	return &mcp.EmbeddedResource{
+		Resource: &mcp.ResourceContents{URI: uri, MIMEType: mimeType, Text: text},
+	}, nil
+}
+
+// validateMarkers checks the markers of an unrendered prompt body. Marker
+// values containing template actions are only known after rendering and are
+// checked then.
+func validateMarkers(body string) error {
+	for _, m := range markerPattern.FindAllStringSubmatch(body, -1) {
+		kind, value := m[1], m[2]
+		if strings.Contains(value, "{{") {
+			continue
+		}
+		switch kind {
+		case markerMessage:
+			if err := validateRole(value); err != nil {
+				return err
+			}
+		case markerResource:
+			if value == "" {
+				return fmt.Errorf("resource marker is missing a URI")
+			}
+		}
+	}
+	return nil
+}
+
+func validateRole(role string) error {
+	if role != roleUser && role != roleAssistant {
+		return fmt.Errorf("invalid message role %q, must be %q or %q", role, roleUser, roleAssistant)
+	}
+	return nil
+}
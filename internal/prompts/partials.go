@@ -0,0 +1,151 @@
+package prompts
+
+import (
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/sha1n/mcp-acdc-server/internal/content"
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+)
+
+// partialsDirName is the directory under mcp-prompts holding shared templates.
+// Partials are not prompts and are excluded from prompt discovery.
+const partialsDirName = "_partials"
+
+// loadPartials parses every markdown file under the partials directory into
+// a template set that prompts are parsed into. Each partial is named after its
+// path relative to the partials directory without extension, e.g.
+// "review-checklist" or "review/checklist". Frontmatter is optional.
+func loadPartials(cp *content.ContentProvider, partialsDir string) (*template.Template, []domain.Issue, error) {
+	base := template.New("").Option("missingkey=zero").Funcs(NewFuncMap(nil, nil))
+	var issues []domain.Issue
+
+	if _, err := os.Stat(partialsDir); err != nil {
+		if os.IsNotExist(err) {
+			return base, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	err := filepath.WalkDir(partialsDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			slog.Error("Error walking partials directory", "path", path, "error", err)
+			return nil // continue walking
+		}
+		if d.IsDir() || filepath.Ext(path) != ".md" {
+			return nil
+		}
+
+		body, err := loadPartialBody(cp, path)
+		if err != nil {
+			slog.Warn("Skipping invalid partial", "file", d.Name(), "error", err)
+			issues = append(issues, domain.NewError(domain.RuleFrontmatter, path, "%v", err))
+			return nil
+		}
+
+		rel, err := filepath.Rel(partialsDir, path)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(strings.TrimSuffix(rel, filepath.Ext(rel)))
+
+		if _, err := base.New(name).Parse(body); err != nil {
+			slog.Warn("Skipping partial with invalid template", "file", d.Name(), "error", err)
+			issues = append(issues, domain.NewError(domain.RuleTemplate, path, "%v", err))
+			return nil
+		}
+
+		slog.Info("Loaded prompt partial", "name", name)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return base, issues, nil
+}
+
+// loadPartialBody returns the template body of a partial, without frontmatter if present
+func loadPartialBody(cp *content.ContentProvider, path string) (string, error) {
+	text, err := cp.LoadText(path)
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(strings.ReplaceAll(text, "\r\n", "\n"), "---\n") {
+		return text, nil
+	}
+	md, err := cp.LoadMarkdownWithFrontmatter(path)
+	if err != nil {
+		return "", err
+	}
+	return md.Content, nil
+}
+
+// undefinedTemplates returns the names of templates invoked with {{template}}
+// by t, or by the templates it invokes, that are not defined in its template set
+func undefinedTemplates(t *template.Template) []string {
+	if t.Tree == nil {
+		return nil
+	}
+	var missing []string
+	seen := map[string]bool{t.Name(): true}
+	var walk func(node parse.Node)
+	walk = func(node parse.Node) {
+		switch n := node.(type) {
+		case *parse.ListNode:
+			if n == nil {
+				return
+			}
+			for _, child := range n.Nodes {
+				walk(child)
+			}
+		case *parse.IfNode:
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.RangeNode:
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.WithNode:
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.TemplateNode:
+			if seen[n.Name] {
+				return
+			}
+			seen[n.Name] = true
+			invoked := t.Lookup(n.Name)
+			if invoked == nil || invoked.Tree == nil {
+				missing = append(missing, n.Name)
+				return
+			}
+			walk(invoked.Tree.Root)
+		}
+	}
+	walk(t.Tree.Root)
+	return missing
+}
+
+// parsePromptTemplate parses a prompt body into a copy of the partials set
+func parsePromptTemplate(partials *template.Template, name, body string) (*template.Template, error) {
+	if partials.Lookup(name) != nil {
+		return nil, fmt.Errorf("prompt name %q conflicts with a partial of the same name", name)
+	}
+	set, err := partials.Clone()
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := set.New(name).Parse(body)
+	if err != nil {
+		return nil, err
+	}
+	if missing := undefinedTemplates(tmpl); len(missing) > 0 {
+		return nil, fmt.Errorf("template %q is not defined", missing[0])
+	}
+	return tmpl, nil
+}
@@ -0,0 +1,91 @@
+package prompts
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sha1n/mcp-acdc-server/internal/content"
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writePromptFiles(t *testing.T, files map[string]string) *content.ContentProvider {
+	t.Helper()
+	tempDir := t.TempDir()
+	for name, body := range files {
+		path := filepath.Join(tempDir, "mcp-prompts", filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(body), 0644))
+	}
+	return content.NewContentProvider(tempDir)
+}
+
+func TestDiscoverPrompts_Partials(t *testing.T) {
+	cp := writePromptFiles(t, map[string]string{
+		"_partials/review-checklist.md": "- Tests for {{.area}}\n- Docs",
+		"_partials/format/output.md":    "---\ndescription: Output rules\n---\nAnswer in {{.format | default \"markdown\"}}.",
+		"_partials/layout.md":           "Start\n{{template \"body\" .}}\nEnd",
+		"review.md": `---
+name: review
+description: Review
+---
+Review {{.area}}:
+{{template "review-checklist" .}}
+{{template "format/output" .}}`,
+		"page.md": "---\nname: page\ndescription: Page\n---\n{{define \"body\"}}Middle{{end}}{{template \"layout\" .}}",
+	})
+
+	defs, issues, err := DiscoverPromptsWithIssues(cp)
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+	require.Len(t, defs, 2, "partials should not be listed as prompts")
+
+	p := NewPromptProvider(defs, cp)
+
+	messages, err := p.GetPrompt("review", map[string]string{"area": "api"})
+	require.NoError(t, err)
+	assert.Equal(t, "Review api:\n- Tests for api\n- Docs\nAnswer in markdown.", messages[0].Content.(*mcp.TextContent).Text)
+
+	messages, err = p.GetPrompt("page", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Start\nMiddle\nEnd", messages[0].Content.(*mcp.TextContent).Text)
+
+	for _, prompt := range p.ListPrompts() {
+		assert.NotContains(t, []string{"review-checklist", "format/output"}, prompt.Name)
+	}
+}
+
+func TestDiscoverPromptsWithIssues_Partials(t *testing.T) {
+	cp := writePromptFiles(t, map[string]string{
+		"_partials/broken.md":      "{{.unclosed",
+		"_partials/bad-fm.md":      "---\nname: [unclosed\n---\nBody",
+		"_partials/ok.md":          "OK",
+		"uses-ok.md":               "---\nname: uses-ok\ndescription: d\n---\n{{template \"ok\" .}}",
+		"uses-missing.md":          "---\nname: uses-missing\ndescription: d\n---\n{{template \"missing\" .}}",
+		"uses-broken.md":           "---\nname: uses-broken\ndescription: d\n---\n{{if .x}}{{template \"broken\" .}}{{end}}",
+		"_partials/nested/deep.md": "{{template \"also-missing\" .}}",
+		"uses-deep.md":             "---\nname: uses-deep\ndescription: d\n---\n{{template \"nested/deep\" .}}",
+		"conflict.md":              "---\nname: ok\ndescription: d\n---\nShadows the partial",
+	})
+
+	defs, issues, err := DiscoverPromptsWithIssues(cp)
+	require.NoError(t, err)
+	require.Len(t, defs, 1)
+	assert.Equal(t, "uses-ok", defs[0].Name)
+
+	messages := make(map[string]string)
+	for _, issue := range issues {
+		assert.Equal(t, domain.SeverityError, issue.Severity)
+		messages[filepath.Base(issue.File)] = issue.Message
+	}
+	assert.Len(t, messages, 6)
+	assert.Contains(t, messages["broken.md"], "unclosed action")
+	assert.Contains(t, messages["bad-fm.md"], "invalid YAML")
+	assert.Contains(t, messages["uses-missing.md"], `template "missing" is not defined`)
+	assert.Contains(t, messages["uses-broken.md"], `template "broken" is not defined`)
+	assert.Contains(t, messages["uses-deep.md"], `template "also-missing" is not defined`)
+	assert.Contains(t, messages["conflict.md"], `prompt name "ok" conflicts with a partial`)
+}
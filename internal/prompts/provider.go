@@ -1,16 +1,17 @@
 package prompts
 
 import (
-	"bytes"
 	"fmt"
 	"io/fs"
 	"log/slog"
 	"os"
 	"path/filepath"
-	"text/template"
+	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/sha1n/mcp-acdc-server/internal/content"
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+	"github.com/sha1n/mcp-acdc-server/internal/search"
 )
 
 // PromptProvider provides access to prompts
@@ -18,19 +19,25 @@ type PromptProvider struct {
 	definitions []PromptDefinition
 	nameMap     map[string]PromptDefinition
 	cp          *content.ContentProvider
+	resources   Resources
+	searcher    search.Searcher
 }
 
 // NewPromptProvider creates a new prompt provider
-func NewPromptProvider(definitions []PromptDefinition, cp *content.ContentProvider) *PromptProvider {
+func NewPromptProvider(definitions []PromptDefinition, cp *content.ContentProvider, opts ...Option) *PromptProvider {
 	nameMap := make(map[string]PromptDefinition)
 	for _, d := range definitions {
 		nameMap[d.Name] = d
 	}
-	return &PromptProvider{
+	p := &PromptProvider{
 		definitions: definitions,
 		nameMap:     nameMap,
 		cp:          cp,
 	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
 }
 
 // ListPrompts lists all available prompts
@@ -41,8 +48,8 @@ func (p *PromptProvider) ListPrompts() []mcp.Prompt {
 		for j, a := range d.Arguments {
 			args[j] = &mcp.PromptArgument{
 				Name:        a.Name,
-				Description: a.Description,
-				Required:    a.Required,
+				Description: a.describe(),
+				Required:    a.Required && a.Default == nil,
 			}
 		}
 
@@ -55,6 +62,15 @@ func (p *PromptProvider) ListPrompts() []mcp.Prompt {
 	return prompts
 }
 
+// Arguments returns the argument definitions of a prompt
+func (p *PromptProvider) Arguments(name string) ([]PromptArgument, error) {
+	defn, ok := p.nameMap[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown prompt: %s", name)
+	}
+	return defn.Arguments, nil
+}
+
 // GetPrompt renders a prompt by name with arguments
 func (p *PromptProvider) GetPrompt(name string, arguments map[string]string) ([]*mcp.PromptMessage, error) {
 	defn, ok := p.nameMap[name]
@@ -62,52 +78,66 @@ func (p *PromptProvider) GetPrompt(name string, arguments map[string]string) ([]
 		return nil, fmt.Errorf("unknown prompt: %s", name)
 	}
 
-	// Validate required arguments
-	for _, arg := range defn.Arguments {
-		if arg.Required {
-			val, ok := arguments[arg.Name]
-			if !ok || val == "" {
-				return nil, fmt.Errorf("missing required argument: %s", arg.Name)
-			}
-		}
+	// Validate arguments and apply defaults
+	values, err := resolveArguments(defn.Arguments, arguments)
+	if err != nil {
+		return nil, err
 	}
 
-	var buf bytes.Buffer
-	if err := defn.Template.Execute(&buf, arguments); err != nil {
-		return nil, fmt.Errorf("failed to execute prompt template: %w", err)
+	// Bind the content functions to this provider without mutating the shared template
+	tmpl, err := defn.Template.Clone()
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare prompt template: %w", err)
 	}
+	tmpl.Funcs(NewFuncMap(p.resources, p.searcher))
 
-	return []*mcp.PromptMessage{
-		{
-			Role: "user",
-			Content: &mcp.TextContent{
-				Text: buf.String(),
-			},
-		},
-	}, nil
+	segments := defn.segments
+	if segments == nil {
+		segments = []promptSegment{{name: tmpl.Name()}}
+	}
+	return p.buildMessages(tmpl, segments, values)
 }
 
-// DiscoverPrompts discovers prompts from markdown files
+// DiscoverPrompts discovers prompts from markdown files.
+// Invalid files are logged and skipped.
 func DiscoverPrompts(cp *content.ContentProvider) ([]PromptDefinition, error) {
+	definitions, _, err := DiscoverPromptsWithIssues(cp)
+	return definitions, err
+}
+
+// DiscoverPromptsWithIssues discovers prompts like DiscoverPrompts and also
+// returns an issue for every file that was skipped.
+func DiscoverPromptsWithIssues(cp *content.ContentProvider) ([]PromptDefinition, []domain.Issue, error) {
 	var definitions []PromptDefinition
+	nameToPath := make(map[string]string)
 	promptsDir := cp.PromptsDir
 
 	// Ensure directory exists, if not just return empty
 	if _, err := os.Stat(promptsDir); err != nil {
 		if os.IsNotExist(err) {
 			slog.Debug("Prompts directory does not exist", "path", promptsDir)
-			return nil, nil
+			return nil, nil, nil
 		}
 		slog.Error("Failed to access prompts directory", "path", promptsDir, "error", err)
-		return nil, err
+		return nil, nil, err
+	}
+
+	partialsDir := filepath.Join(promptsDir, partialsDirName)
+	partials, issues, err := loadPartials(cp, partialsDir)
+	if err != nil {
+		slog.Error("Failed to load prompt partials", "path", partialsDir, "error", err)
+		return nil, nil, err
 	}
 
-	err := filepath.WalkDir(promptsDir, func(path string, d fs.DirEntry, err error) error {
+	err = filepath.WalkDir(promptsDir, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			slog.Error("Error walking prompts directory", "path", path, "error", err)
 			return nil // continue walking
 		}
 		if d.IsDir() {
+			if path == partialsDir {
+				return filepath.SkipDir
+			}
 			return nil
 		}
 		if filepath.Ext(path) != ".md" {
@@ -118,6 +148,7 @@ func DiscoverPrompts(cp *content.ContentProvider) ([]PromptDefinition, error) {
 		md, err := cp.LoadMarkdownWithFrontmatter(path)
 		if err != nil {
 			slog.Warn("Skipping invalid prompt file", "file", d.Name(), "error", err)
+			issues = append(issues, domain.NewError(domain.RuleFrontmatter, path, "%v", err))
 			return nil
 		}
 
@@ -125,39 +156,47 @@ func DiscoverPrompts(cp *content.ContentProvider) ([]PromptDefinition, error) {
 		name, _ := md.Metadata["name"].(string)
 		description, _ := md.Metadata["description"].(string)
 
-		if name == "" || description == "" {
+		if missing := md.MissingFields("name", "description"); len(missing) > 0 {
 			slog.Warn("Skipping prompt with missing metadata", "file", d.Name())
+			issues = append(issues, domain.NewError(domain.RuleRequiredField, path, "missing required frontmatter field(s): %s", strings.Join(missing, ", ")))
+			return nil
+		}
+
+		if existing, ok := nameToPath[name]; ok {
+			slog.Warn("Skipping prompt with duplicate name", "file", d.Name(), "name", name)
+			issues = append(issues, domain.NewError(domain.RuleDuplicatePrompt, path, "prompt name %q is already used by %s", name, existing))
 			return nil
 		}
 
 		// Extract arguments
-		var arguments []PromptArgument
-		if args, ok := md.Metadata["arguments"].([]interface{}); ok {
-			for _, a := range args {
-				if amap, ok := a.(map[string]interface{}); ok {
-					argName, _ := amap["name"].(string)
-					argDesc, _ := amap["description"].(string)
-					argReq, ok := amap["required"].(bool)
-					if !ok {
-						argReq = true // default to required
-					}
-					if argName != "" {
-						arguments = append(arguments, PromptArgument{
-							Name:        argName,
-							Description: argDesc,
-							Required:    argReq,
-						})
-					}
-				}
-			}
+		arguments, warnings, err := parseArguments(md.Metadata["arguments"])
+		for _, warning := range warnings {
+			slog.Warn("Ignoring invalid argument setting", "file", d.Name(), "warning", warning)
+			issues = append(issues, domain.NewWarning(domain.RuleFrontmatter, path, "%s", warning))
+		}
+		if err != nil {
+			slog.Warn("Skipping prompt with invalid arguments", "file", d.Name(), "error", err)
+			issues = append(issues, domain.NewError(domain.RuleFrontmatter, path, "%v", err))
+			return nil
 		}
 
 		// Parse and cache template
-		tmpl, err := template.New(name).Option("missingkey=zero").Parse(md.Content)
+		tmpl, err := parsePromptTemplate(partials, name, md.Content)
+		if err == nil {
+			err = validateMarkers(md.Content)
+		}
+		var segments []promptSegment
+		if err == nil {
+			segments, err = parseSegments(tmpl, md.Content)
+		}
 		if err != nil {
 			slog.Warn("Skipping prompt with invalid template", "file", d.Name(), "error", err)
+			issues = append(issues, domain.NewError(domain.RuleTemplate, path, "%v", err))
 			return nil
 		}
+		// path is within promptsDir, so Rel cannot fail
+		rel, _ := filepath.Rel(promptsDir, path)
+		nameToPath[name] = filepath.ToSlash(rel)
 
 		definitions = append(definitions, PromptDefinition{
 			Name:        name,
@@ -165,6 +204,7 @@ func DiscoverPrompts(cp *content.ContentProvider) ([]PromptDefinition, error) {
 			Arguments:   arguments,
 			FilePath:    path,
 			Template:    tmpl,
+			segments:    segments,
 		})
 
 		slog.Info("Loaded prompt", "name", name)
@@ -172,5 +212,5 @@ func DiscoverPrompts(cp *content.ContentProvider) ([]PromptDefinition, error) {
 		return nil
 	})
 
-	return definitions, err
+	return definitions, issues, err
 }
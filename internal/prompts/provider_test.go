@@ -7,6 +7,7 @@ import (
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/sha1n/mcp-acdc-server/internal/content"
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -162,6 +163,47 @@ Hello {{.unclosed`
 	})
 }
 
+func TestDiscoverPromptsWithIssues(t *testing.T) {
+	tempDir := t.TempDir()
+	promptsDir := filepath.Join(tempDir, "mcp-prompts")
+	_ = os.MkdirAll(filepath.Join(promptsDir, "team"), 0755)
+
+	files := map[string]string{
+		"a.md":      "---\nname: review\ndescription: d\n---\nReview",
+		"team/b.md": "---\nname: review\ndescription: d\n---\nAnother review",
+		"c.md":      "---\nname: broken\ndescription: d\n---\n{{.unclosed",
+		"d.md":      "---\nname: nodesc\n---\nBody",
+		"e.md":      "no frontmatter",
+	}
+	for name, body := range files {
+		assert.NoError(t, os.WriteFile(filepath.Join(promptsDir, name), []byte(body), 0644))
+	}
+
+	defs, issues, err := DiscoverPromptsWithIssues(content.NewContentProvider(tempDir))
+	assert.NoError(t, err)
+	assert.Len(t, defs, 1)
+	assert.Equal(t, filepath.Join(promptsDir, "a.md"), defs[0].FilePath)
+
+	rules := make(map[string]string)
+	for _, issue := range issues {
+		assert.Equal(t, domain.SeverityError, issue.Severity)
+		rel, _ := filepath.Rel(promptsDir, issue.File)
+		rules[filepath.ToSlash(rel)] = issue.Rule
+	}
+	assert.Equal(t, map[string]string{
+		"team/b.md": domain.RuleDuplicatePrompt,
+		"c.md":      domain.RuleTemplate,
+		"d.md":      domain.RuleRequiredField,
+		"e.md":      domain.RuleFrontmatter,
+	}, rules)
+
+	for _, issue := range issues {
+		if issue.Rule == domain.RuleDuplicatePrompt {
+			assert.Contains(t, issue.Message, `"review" is already used by a.md`)
+		}
+	}
+}
+
 func TestPromptProvider_GetPrompt(t *testing.T) {
 	tempDir := t.TempDir()
 	cp := content.NewContentProvider(tempDir)
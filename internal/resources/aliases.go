@@ -0,0 +1,133 @@
+package resources
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/sha1n/mcp-acdc-server/internal/content"
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+)
+
+const (
+	// aliasesField is the frontmatter field listing the former URIs of a
+	// resource
+	aliasesField = "aliases"
+	// redirectsFileName is the file in the resources directory mapping former
+	// URIs to the URIs of the resources that replace them
+	redirectsFileName = "_redirects.yaml"
+)
+
+// parseAliases extracts the aliases frontmatter field, a URI or list of URIs.
+// Invalid values are logged and ignored.
+func parseAliases(metadata map[string]interface{}, scheme, fileName string) []string {
+	var aliases []string
+	switch v := metadata[aliasesField].(type) {
+	case nil:
+	case string:
+		aliases = append(aliases, normalizeURI(v, scheme))
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok && s != "" {
+				aliases = append(aliases, normalizeURI(s, scheme))
+			} else {
+				slog.Warn("Ignoring invalid alias", "file", fileName, "value", item)
+			}
+		}
+	default:
+		slog.Warn("Ignoring invalid aliases", "file", fileName, "value", v)
+	}
+	return aliases
+}
+
+// loadRedirects loads the redirects file of the resources directory, if any,
+// as a map of former URIs to target URIs
+func loadRedirects(cp *content.ContentProvider, scheme string) (map[string]string, error) {
+	path := filepath.Join(cp.ResourcesDir, redirectsFileName)
+	if _, err := os.Stat(path); err != nil {
+		return nil, nil
+	}
+	raw, err := cp.LoadYAML(path)
+	if err != nil {
+		return nil, err
+	}
+
+	redirects := make(map[string]string, len(raw))
+	for from, value := range raw {
+		to, ok := value.(string)
+		if !ok || to == "" {
+			return nil, fmt.Errorf("redirect of %q in %s must be a URI", from, path)
+		}
+		redirects[normalizeURI(from, scheme)] = normalizeURI(to, scheme)
+	}
+	return redirects, nil
+}
+
+// applyAliases adds the redirects to the aliases of their target resources and
+// drops aliases that are the URI of a resource or an alias of another
+// resource, reporting each as an issue. Redirects to an alias are followed to
+// its resource.
+func applyAliases(definitions []ResourceDefinition, redirects map[string]string, redirectsPath string) []domain.Issue {
+	var issues []domain.Issue
+	index := make(map[string]int, len(definitions))
+	for i, d := range definitions {
+		index[d.URI] = i
+	}
+
+	owner := make(map[string]int) // Alias to the index of its resource
+	addAlias := func(i int, alias, file string) {
+		if _, isURI := index[alias]; isURI {
+			slog.Warn("Ignoring alias that is the URI of a resource", "file", file, "alias", alias)
+			issues = append(issues, domain.NewError(domain.RuleAlias, file, "alias %s is the URI of a resource", alias))
+			return
+		}
+		if existing, isAlias := owner[alias]; isAlias {
+			if existing != i {
+				slog.Warn("Ignoring duplicate alias", "file", file, "alias", alias)
+				issues = append(issues, domain.NewError(domain.RuleAlias, file, "alias %s is already an alias of %s", alias, definitions[existing].URI))
+			}
+			return
+		}
+		owner[alias] = i
+	}
+
+	for i, d := range definitions {
+		for _, alias := range d.Aliases {
+			addAlias(i, alias, d.FilePath)
+		}
+	}
+
+	froms := make([]string, 0, len(redirects))
+	for from := range redirects {
+		froms = append(froms, from)
+	}
+	sort.Strings(froms)
+	for _, from := range froms {
+		to := redirects[from]
+		i, ok := index[to]
+		if !ok {
+			if i, ok = owner[to]; !ok {
+				slog.Warn("Ignoring redirect to unknown resource", "from", from, "to", to)
+				issues = append(issues, domain.NewError(domain.RuleAlias, redirectsPath, "redirect of %s points to unknown resource %s", from, to))
+				continue
+			}
+		}
+		addAlias(i, from, redirectsPath)
+	}
+
+	for i := range definitions {
+		definitions[i].Aliases = nil
+	}
+	aliases := make([]string, 0, len(owner))
+	for alias := range owner {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	for _, alias := range aliases {
+		i := owner[alias]
+		definitions[i].Aliases = append(definitions[i].Aliases, alias)
+	}
+	return issues
+}
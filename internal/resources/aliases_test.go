@@ -0,0 +1,96 @@
+package resources
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/sha1n/mcp-acdc-server/internal/content"
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeURI(t *testing.T) {
+	assert.Equal(t, "acdc://old/guide", normalizeURI("old/guide", "acdc"))
+	assert.Equal(t, "acdc://old/guide", normalizeURI("/old/guide/", "acdc"))
+	assert.Equal(t, "other://old", normalizeURI("other://old", "acdc"))
+}
+
+func TestDiscoverResources_Aliases(t *testing.T) {
+	tmp := writeResources(t, map[string]string{
+		"guides/setup.md":  "---\nname: Setup\ndescription: Setup guide\naliases: [setup, acdc://getting-started/setup]\n---\nSetup",
+		"guides/deploy.md": "---\nname: Deploy\ndescription: Deploy guide\naliases:\n  - setup\n  - guides/setup\n---\nDeploy",
+		"guides/single.md": "---\nname: Single\ndescription: Single alias\naliases: one\n---\nSingle",
+		"_redirects.yaml":  "legacy/setup: getting-started/setup\nold/deploy: acdc://guides/deploy\nold/missing: guides/missing\n",
+	})
+	cp := content.NewContentProvider(tmp)
+
+	defs, issues, err := DiscoverResourcesWithIssues(cp, "acdc")
+	require.NoError(t, err)
+
+	aliases := make(map[string][]string)
+	for _, d := range defs {
+		aliases[d.URI] = d.Aliases
+	}
+	assert.Equal(t, map[string][]string{
+		"acdc://guides/setup":  {"acdc://getting-started/setup", "acdc://legacy/setup"},
+		"acdc://guides/deploy": {"acdc://old/deploy", "acdc://setup"},
+		"acdc://guides/single": {"acdc://one"},
+	}, aliases)
+
+	redirectsPath := filepath.Join(cp.ResourcesDir, redirectsFileName)
+	require.Len(t, issues, 3)
+	for _, issue := range issues {
+		assert.Equal(t, domain.RuleAlias, issue.Rule)
+	}
+	// Files are walked in lexical order, so deploy.md claims the shared alias first
+	assert.Contains(t, issues[0].Message, "alias acdc://guides/setup is the URI of a resource")
+	assert.Contains(t, issues[1].Message, "alias acdc://setup is already an alias of acdc://guides/deploy")
+	assert.Equal(t, redirectsPath, issues[2].File)
+	assert.Contains(t, issues[2].Message, "points to unknown resource acdc://guides/missing")
+}
+
+func TestDiscoverResources_InvalidRedirects(t *testing.T) {
+	tmp := writeResources(t, map[string]string{
+		"guide.md":        "---\nname: Guide\ndescription: Guide\n---\nGuide",
+		"_redirects.yaml": "old: [not, a, uri]\n",
+	})
+
+	defs, issues, err := DiscoverResourcesWithIssues(content.NewContentProvider(tmp), "acdc")
+	require.NoError(t, err)
+	assert.Len(t, defs, 1)
+	require.Len(t, issues, 1)
+	assert.Equal(t, domain.RuleAlias, issues[0].Rule)
+	assert.Contains(t, issues[0].Message, "must be a URI")
+}
+
+func TestResourceProvider_Aliases(t *testing.T) {
+	tmp := writeResources(t, map[string]string{
+		"guides/setup.md": "---\nname: Setup\ndescription: Setup guide\n---\nSetup body",
+	})
+	p := NewResourceProvider([]ResourceDefinition{{
+		URI:      "acdc://guides/setup",
+		Name:     "Setup",
+		MIMEType: MIMETypeMarkdown,
+		FilePath: filepath.Join(tmp, "mcp-resources", "guides", "setup.md"),
+		Aliases:  []string{"acdc://setup"},
+	}})
+
+	canonical, ok := p.ResolveAlias("acdc://setup")
+	assert.True(t, ok)
+	assert.Equal(t, "acdc://guides/setup", canonical)
+	_, ok = p.ResolveAlias("acdc://guides/setup")
+	assert.False(t, ok)
+
+	text, err := p.ReadResource("acdc://setup")
+	require.NoError(t, err)
+	assert.Equal(t, "Setup body", text)
+
+	res, ok := p.GetResource("acdc://setup")
+	assert.True(t, ok)
+	assert.Equal(t, "acdc://guides/setup", res.URI)
+
+	listed := p.ListResources()
+	require.Len(t, listed, 1)
+	assert.Equal(t, "acdc://guides/setup", listed[0].URI)
+}
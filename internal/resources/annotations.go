@@ -0,0 +1,101 @@
+package resources
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// annotationsField is the frontmatter field holding MCP annotations
+const annotationsField = "annotations"
+
+// resource returns the MCP listing of the resource
+func (d ResourceDefinition) resource() mcp.Resource {
+	return mcp.Resource{
+		URI:         d.URI,
+		Name:        d.Name,
+		Description: d.Description,
+		MIMEType:    d.MIMEType,
+		Size:        d.Size,
+		Annotations: d.annotations(),
+	}
+}
+
+// annotations returns the MCP annotations of the resource, or nil if it has none
+func (d ResourceDefinition) annotations() *mcp.Annotations {
+	if len(d.Audience) == 0 && d.Importance == nil && d.LastModified == nil {
+		return nil
+	}
+
+	a := &mcp.Annotations{}
+	for _, role := range d.Audience {
+		a.Audience = append(a.Audience, mcp.Role(role))
+	}
+	if d.Importance != nil {
+		a.Priority = *d.Importance
+	}
+	if d.LastModified != nil {
+		a.LastModified = d.LastModified.UTC().Format(time.RFC3339)
+	}
+	return a
+}
+
+// parseAnnotations extracts the optional audience and priority of the
+// annotations frontmatter field. Invalid values are logged and ignored.
+func parseAnnotations(metadata map[string]interface{}, fileName string) ([]string, *float64) {
+	raw, ok := metadata[annotationsField]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	fields, ok := raw.(map[string]interface{})
+	if !ok {
+		slog.Warn("Ignoring invalid annotations", "file", fileName, "value", raw)
+		return nil, nil
+	}
+
+	var audience []string
+	switch v := fields["audience"].(type) {
+	case nil:
+	case string:
+		audience = appendRole(audience, v, fileName)
+	case []interface{}:
+		for _, item := range v {
+			role, _ := item.(string)
+			audience = appendRole(audience, role, fileName)
+		}
+	default:
+		slog.Warn("Ignoring invalid annotation audience", "file", fileName, "value", v)
+	}
+
+	var priority *float64
+	switch v := fields["priority"].(type) {
+	case nil:
+	case int:
+		priority = validPriority(float64(v), fileName)
+	case float64:
+		priority = validPriority(v, fileName)
+	default:
+		slog.Warn("Ignoring non-numeric annotation priority", "file", fileName, "value", v)
+	}
+
+	return audience, priority
+}
+
+// appendRole appends a valid audience role
+func appendRole(audience []string, role, fileName string) []string {
+	if role != "user" && role != "assistant" {
+		slog.Warn("Ignoring invalid annotation audience, must be user or assistant", "file", fileName, "value", role)
+		return audience
+	}
+	return append(audience, role)
+}
+
+// validPriority returns the priority if it is between 0 and 1
+func validPriority(priority float64, fileName string) *float64 {
+	if priority < 0 || priority > 1 {
+		slog.Warn("Ignoring annotation priority, must be between 0 and 1", "file", fileName, "value", priority)
+		return nil
+	}
+	return &priority
+}
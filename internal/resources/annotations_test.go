@@ -0,0 +1,64 @@
+package resources
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sha1n/mcp-acdc-server/internal/content"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscoverResources_Annotations(t *testing.T) {
+	tmp := t.TempDir()
+	resDir := filepath.Join(tmp, "mcp-resources")
+	require.NoError(t, os.MkdirAll(resDir, 0755))
+
+	files := map[string]string{
+		"annotated.md": "---\nname: Annotated\ndescription: D\npriority: 5\nannotations:\n  audience: [assistant, user]\n  priority: 0.8\n---\nContent",
+		"single.md":    "---\nname: Single\ndescription: D\nannotations:\n  audience: user\n---\nContent",
+		"invalid.md":   "---\nname: Invalid\ndescription: D\nannotations:\n  audience: [robot]\n  priority: 3\n---\nContent",
+	}
+	modTime := time.Date(2024, 6, 1, 8, 30, 0, 0, time.UTC)
+	for name, body := range files {
+		path := filepath.Join(resDir, name)
+		require.NoError(t, os.WriteFile(path, []byte(body), 0644))
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+
+	defs, err := DiscoverResources(content.NewContentProvider(tmp), "acdc")
+	require.NoError(t, err)
+	provider := NewResourceProvider(defs)
+
+	annotated, ok := provider.GetResource("acdc://annotated")
+	require.True(t, ok)
+	assert.Equal(t, int64(len("Content")), annotated.Size)
+	assert.Equal(t, &mcp.Annotations{
+		Audience:     []mcp.Role{"assistant", "user"},
+		Priority:     0.8,
+		LastModified: "2024-06-01T08:30:00Z",
+	}, annotated.Annotations, "the ranking priority does not affect the annotation priority")
+
+	single, _ := provider.GetResource("acdc://single")
+	assert.Equal(t, []mcp.Role{"user"}, single.Annotations.Audience)
+
+	invalid, _ := provider.GetResource("acdc://invalid")
+	assert.Equal(t, &mcp.Annotations{LastModified: "2024-06-01T08:30:00Z"}, invalid.Annotations)
+
+	_, ok = provider.GetResource("acdc://missing")
+	assert.False(t, ok)
+
+	for _, res := range provider.ListResources() {
+		got, _ := provider.GetResource(res.URI)
+		assert.Equal(t, got, res, "listing and lookup agree")
+	}
+}
+
+func TestResourceDefinition_NoAnnotations(t *testing.T) {
+	res := ResourceDefinition{URI: "acdc://a", Name: "A", MIMEType: "text/markdown"}.resource()
+	assert.Nil(t, res.Annotations)
+	assert.Zero(t, res.Size)
+}
@@ -0,0 +1,236 @@
+package resources
+
+import (
+	"fmt"
+	"log/slog"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sha1n/mcp-acdc-server/internal/content"
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+)
+
+// attachmentsField is the frontmatter field listing the attachments of a
+// markdown resource
+const attachmentsField = "attachments"
+
+// attachmentMIMETypes maps the extensions of common binary attachments to
+// their MIME types. Other extensions fall back to the types of non-markdown
+// resources, then to the system MIME table.
+var attachmentMIMETypes = map[string]string{
+	".pdf":  MIMETypePDF,
+	".docx": MIMETypeDOCX,
+	".html": MIMETypeHTML,
+	".htm":  MIMETypeHTML,
+	".png":  "image/png",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".gif":  "image/gif",
+	".webp": "image/webp",
+	".svg":  "image/svg+xml",
+}
+
+// attachmentMIMEType returns the MIME type of an attachment file
+func attachmentMIMEType(path string) string {
+	ext := strings.ToLower(filepath.Ext(path))
+	if mimeType, ok := attachmentMIMETypes[ext]; ok {
+		return mimeType
+	}
+	if mimeType, ok := mimeTypeOf(path); ok {
+		return mimeType
+	}
+	if mimeType := mime.TypeByExtension(ext); mimeType != "" {
+		mimeType, _, _ = strings.Cut(mimeType, ";")
+		return mimeType
+	}
+	return "application/octet-stream"
+}
+
+// isTextMIMEType reports whether content of the MIME type is readable as-is
+func isTextMIMEType(mimeType string) bool {
+	if strings.HasPrefix(mimeType, "text/") {
+		return true
+	}
+	for _, t := range mimeTypes {
+		if t == mimeType {
+			return true
+		}
+	}
+	return false
+}
+
+// isAttachment reports whether the resource is attached to a markdown resource
+func (d ResourceDefinition) isAttachment() bool {
+	return d.AttachedTo != ""
+}
+
+// attachmentSpec is an entry of the attachments frontmatter field
+type attachmentSpec struct {
+	path        string
+	name        string
+	description string
+	mimeType    string
+}
+
+// parseAttachmentSpecs parses the attachments frontmatter field. Entries are
+// either a path or a map with a path and optional name, description and
+// mime_type. Invalid entries are returned as errors.
+func parseAttachmentSpecs(metadata map[string]interface{}) ([]attachmentSpec, []error) {
+	raw, ok := metadata[attachmentsField]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	items, ok := raw.([]interface{})
+	if !ok {
+		return nil, []error{fmt.Errorf("%s must be a list", attachmentsField)}
+	}
+
+	var specs []attachmentSpec
+	var errs []error
+	for i, item := range items {
+		var spec attachmentSpec
+		switch v := item.(type) {
+		case string:
+			spec.path = v
+		case map[string]interface{}:
+			spec.path, _ = v["path"].(string)
+			spec.name, _ = v["name"].(string)
+			spec.description, _ = v["description"].(string)
+			spec.mimeType, _ = v["mime_type"].(string)
+		default:
+			errs = append(errs, fmt.Errorf("attachment %d must be a path or a map with a path", i+1))
+			continue
+		}
+		if spec.path == "" {
+			errs = append(errs, fmt.Errorf("attachment %d has no path", i+1))
+			continue
+		}
+		specs = append(specs, spec)
+	}
+	return specs, errs
+}
+
+// attachmentDiscovery turns the attachments declared by markdown resources
+// into resource definitions
+type attachmentDiscovery struct {
+	resourcesDir string
+	scheme       string
+	uriToPath    map[string]string // Shared with resource discovery for duplicate detection
+	lastModified *content.LastModifiedResolver
+}
+
+// discover returns the definitions of the attachments of parent, declared in
+// its frontmatter metadata, and sets the attachment URIs of parent. Attachment
+// paths are relative to the directory of parent and must stay within the
+// resources directory. Attachments inherit the keywords, ranking metadata and
+// annotations of parent.
+func (a *attachmentDiscovery) discover(parent *ResourceDefinition, metadata map[string]interface{}) ([]ResourceDefinition, []domain.Issue) {
+	specs, errs := parseAttachmentSpecs(metadata)
+	var issues []domain.Issue
+	for _, err := range errs {
+		slog.Warn("Ignoring invalid attachment", "file", parent.FilePath, "error", err)
+		issues = append(issues, domain.NewError(domain.RuleAttachment, parent.FilePath, "%v", err))
+	}
+
+	var definitions []ResourceDefinition
+	for _, spec := range specs {
+		defn, err := a.definition(parent, spec)
+		if err != nil {
+			slog.Warn("Ignoring invalid attachment", "file", parent.FilePath, "attachment", spec.path, "error", err)
+			rule := domain.RuleAttachment
+			if _, ok := err.(duplicateURIError); ok {
+				rule = domain.RuleDuplicateURI
+			}
+			issues = append(issues, domain.NewError(rule, parent.FilePath, "attachment %s: %v", spec.path, err))
+			continue
+		}
+		parent.Attachments = append(parent.Attachments, defn.URI)
+		if defn.FilePath != "" {
+			definitions = append(definitions, defn)
+			slog.Info("Loaded attachment", "uri", defn.URI, "resource", parent.URI)
+		}
+	}
+	return definitions, issues
+}
+
+// duplicateURIError reports an attachment URI used by another resource
+type duplicateURIError struct {
+	uri, existing string
+}
+
+func (e duplicateURIError) Error() string {
+	return fmt.Sprintf("URI %s is already used by %s", e.uri, e.existing)
+}
+
+// definition returns the definition of an attachment of parent. A file
+// attached by several resources is defined once; later attachments return a
+// definition with only the URI set.
+func (a *attachmentDiscovery) definition(parent *ResourceDefinition, spec attachmentSpec) (ResourceDefinition, error) {
+	if filepath.IsAbs(spec.path) {
+		return ResourceDefinition{}, fmt.Errorf("path must be relative to the resource file")
+	}
+	path := filepath.Join(filepath.Dir(parent.FilePath), filepath.FromSlash(spec.path))
+	relPath, err := filepath.Rel(a.resourcesDir, path)
+	if err != nil || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+		return ResourceDefinition{}, fmt.Errorf("path is outside of the resources directory")
+	}
+	if filepath.Ext(path) == markdownExt {
+		return ResourceDefinition{}, fmt.Errorf("markdown files cannot be attachments, link to them instead")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return ResourceDefinition{}, fmt.Errorf("file not found")
+	}
+	if info.IsDir() {
+		return ResourceDefinition{}, fmt.Errorf("path is a directory")
+	}
+
+	slashPath := filepath.ToSlash(relPath)
+	uri := fmt.Sprintf("%s://%s", a.scheme, slashPath)
+	if existing, ok := a.uriToPath[uri]; ok {
+		if existing == slashPath {
+			return ResourceDefinition{URI: uri}, nil
+		}
+		return ResourceDefinition{}, duplicateURIError{uri: uri, existing: existing}
+	}
+	a.uriToPath[uri] = slashPath
+
+	name := spec.name
+	if name == "" {
+		name = filepath.Base(path)
+	}
+	description := spec.description
+	if description == "" {
+		description = fmt.Sprintf("Attachment of %s", parent.Name)
+	}
+	mimeType := spec.mimeType
+	if mimeType == "" {
+		mimeType = attachmentMIMEType(path)
+	}
+
+	var modified *time.Time
+	if t, err := a.lastModified.LastModified(path); err == nil {
+		modified = &t
+	}
+
+	return ResourceDefinition{
+		URI:          uri,
+		Name:         name,
+		Description:  description,
+		MIMEType:     mimeType,
+		FilePath:     path,
+		Keywords:     parent.Keywords,
+		Priority:     parent.Priority,
+		Updated:      parent.Updated,
+		Deprecated:   parent.Deprecated,
+		Audience:     parent.Audience,
+		Importance:   parent.Importance,
+		Size:         info.Size(),
+		LastModified: modified,
+		AttachedTo:   parent.URI,
+	}, nil
+}
@@ -0,0 +1,150 @@
+package resources
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sha1n/mcp-acdc-server/internal/content"
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachmentMIMEType(t *testing.T) {
+	assert.Equal(t, MIMETypePDF, attachmentMIMEType("handbook.PDF"))
+	assert.Equal(t, "image/png", attachmentMIMEType("diagram.png"))
+	assert.Equal(t, "text/csv", attachmentMIMEType("contacts.csv"))
+	assert.Equal(t, "application/octet-stream", attachmentMIMEType("archive.unknown-ext"))
+}
+
+func TestDiscoverResources_Attachments(t *testing.T) {
+	tmp := t.TempDir()
+	resDir := filepath.Join(tmp, "mcp-resources")
+	files := map[string]string{
+		"ops/runbook.md": `---
+name: Runbook
+description: Incident runbook
+keywords: [incident]
+annotations:
+  audience: [assistant]
+attachments:
+  - handbook.html
+  - path: ../shared/diagram.png
+    name: Architecture Diagram
+    description: System overview
+  - path: missing.pdf
+  - path: ../../outside.pdf
+  - path: other.md
+  - 42
+---
+Runbook body`,
+		"ops/handbook.html":  "<p>Escalate to the <b>duty manager</b>.</p>",
+		"ops/other.md":       "---\nname: Other\ndescription: Other\n---\nOther",
+		"shared/diagram.png": "\x89PNG",
+		"shared/faq.md":      "---\nname: FAQ\ndescription: FAQ\nattachments: [diagram.png]\n---\nFAQ",
+		"bad.md":             "---\nname: Bad\ndescription: Bad\nattachments: handbook.pdf\n---\nBad",
+	}
+	for name, body := range files {
+		path := filepath.Join(resDir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(body), 0644))
+	}
+
+	defs, issues, err := DiscoverResourcesWithIssues(content.NewContentProvider(tmp), "acdc")
+	require.NoError(t, err)
+
+	byURI := make(map[string]ResourceDefinition)
+	for _, d := range defs {
+		byURI[d.URI] = d
+	}
+
+	runbook := byURI["acdc://ops/runbook"]
+	assert.Equal(t, []string{"acdc://ops/handbook.html", "acdc://shared/diagram.png"}, runbook.Attachments)
+
+	handbook := byURI["acdc://ops/handbook.html"]
+	assert.Equal(t, "handbook.html", handbook.Name)
+	assert.Equal(t, "Attachment of Runbook", handbook.Description)
+	assert.Equal(t, MIMETypeHTML, handbook.MIMEType)
+	assert.Equal(t, "acdc://ops/runbook", handbook.AttachedTo)
+	assert.Equal(t, []string{"incident"}, handbook.Keywords)
+	assert.Equal(t, []string{"assistant"}, handbook.Audience)
+	assert.Equal(t, int64(len(files["ops/handbook.html"])), handbook.Size)
+
+	diagram := byURI["acdc://shared/diagram.png"]
+	assert.Equal(t, "Architecture Diagram", diagram.Name)
+	assert.Equal(t, "System overview", diagram.Description)
+	assert.Equal(t, "image/png", diagram.MIMEType)
+
+	// A file attached by several resources is defined once
+	assert.Equal(t, []string{"acdc://shared/diagram.png"}, byURI["acdc://shared/faq"].Attachments)
+	assert.Len(t, defs, 6)
+
+	var attachmentIssues []domain.Issue
+	for _, issue := range issues {
+		if issue.Rule == domain.RuleAttachment {
+			attachmentIssues = append(attachmentIssues, issue)
+		}
+	}
+	require.Len(t, attachmentIssues, 5)
+	assert.Contains(t, attachmentIssues[0].Message, "must be a list")
+	assert.Contains(t, attachmentIssues[1].Message, "attachment 6 must be a path")
+	assert.Contains(t, attachmentIssues[2].Message, "missing.pdf: file not found")
+	assert.Contains(t, attachmentIssues[3].Message, "outside of the resources directory")
+	assert.Contains(t, attachmentIssues[4].Message, "markdown files cannot be attachments")
+}
+
+func TestResourceProvider_Attachments(t *testing.T) {
+	tmp := t.TempDir()
+	htmlPath := filepath.Join(tmp, "handbook.html")
+	pngPath := filepath.Join(tmp, "diagram.png")
+	require.NoError(t, os.WriteFile(htmlPath, []byte("<p>Escalate now</p>"), 0644))
+	require.NoError(t, os.WriteFile(pngPath, []byte("\x89PNG"), 0644))
+
+	p := NewResourceProvider([]ResourceDefinition{
+		{URI: "acdc://handbook.html", Name: "Handbook", MIMEType: MIMETypeHTML, FilePath: htmlPath, AttachedTo: "acdc://runbook"},
+		{URI: "acdc://diagram.png", Name: "Diagram", MIMEType: "image/png", FilePath: pngPath, AttachedTo: "acdc://runbook"},
+	})
+
+	t.Run("ReadResourceExtractsText", func(t *testing.T) {
+		text, err := p.ReadResource("acdc://handbook.html")
+		require.NoError(t, err)
+		assert.Equal(t, "Escalate now", text)
+
+		_, err = p.ReadResource("acdc://diagram.png")
+		assert.ErrorIs(t, err, errNoExtractor)
+	})
+
+	t.Run("ReadBlob", func(t *testing.T) {
+		data, ok, err := p.ReadBlob("acdc://diagram.png")
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, []byte("\x89PNG"), data)
+
+		_, ok, err = p.ReadBlob("acdc://unknown")
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("StreamResourcesIndexesAttachmentsWithoutText", func(t *testing.T) {
+		ch := make(chan domain.Document, 10)
+		require.NoError(t, p.StreamResources(context.Background(), ch))
+		close(ch)
+
+		contents := make(map[string]string)
+		for doc := range ch {
+			contents[doc.URI] = doc.Content
+		}
+		assert.Equal(t, map[string]string{"acdc://handbook.html": "Escalate now", "acdc://diagram.png": ""}, contents)
+	})
+
+	t.Run("CustomExtractor", func(t *testing.T) {
+		ocr := NewResourceProvider(p.definitions, WithExtractor("image/png", ExtractorFunc(func(data []byte) (string, error) {
+			return "Load balancer", nil
+		})))
+		text, err := ocr.ReadResource("acdc://diagram.png")
+		require.NoError(t, err)
+		assert.Equal(t, "Load balancer", text)
+	})
+}
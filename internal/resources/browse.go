@@ -0,0 +1,128 @@
+package resources
+
+import (
+	"sort"
+	"strings"
+)
+
+// BrowseEntry is a line of the resource tree returned by Browse. Entries are
+// either a folder of resources or a single resource.
+type BrowseEntry struct {
+	Level       int    // 0 for direct children of the browsed prefix
+	Name        string // Folder path segment with a trailing slash, or resource name
+	URI         string // Folder URI with a trailing slash, or resource URI
+	Description string
+	IsFolder    bool
+	Count       int // Number of resources in a folder, including nested folders
+}
+
+// browseNode is a folder of the resource tree
+type browseNode struct {
+	uri       string
+	count     int
+	folders   map[string]*browseNode
+	resources []ResourceDefinition
+}
+
+func newBrowseNode(uri string) *browseNode {
+	return &browseNode{uri: uri, folders: make(map[string]*browseNode)}
+}
+
+// Browse lists the resources whose URI starts with uriPrefix as a tree, in
+// depth-first order. An empty prefix lists every resource. Folders deeper than
+// maxDepth are listed with their resource count but not expanded; a maxDepth
+// of 0 or less expands every folder.
+func (p *ResourceProvider) Browse(uriPrefix string, maxDepth int) []BrowseEntry {
+	root := newBrowseNode(uriPrefix)
+	for _, d := range p.definitions {
+		rel, ok := relativeURI(d.URI, uriPrefix)
+		if !ok {
+			continue
+		}
+
+		node := root
+		base := d.URI[:len(d.URI)-len(rel)]
+		segments := strings.Split(rel, "/")
+		for i, seg := range segments[:len(segments)-1] {
+			child, ok := node.folders[seg]
+			if !ok {
+				child = newBrowseNode(base + strings.Join(segments[:i+1], "/") + "/")
+				node.folders[seg] = child
+			}
+			child.count++
+			node = child
+		}
+		node.resources = append(node.resources, d)
+	}
+
+	var entries []BrowseEntry
+	root.appendEntries(&entries, 0, maxDepth)
+	return entries
+}
+
+// appendEntries appends the folders and resources of n, sorted by path
+// segment, descending into folders up to maxDepth
+func (n *browseNode) appendEntries(entries *[]BrowseEntry, level, maxDepth int) {
+	type child struct {
+		key    string
+		folder *browseNode
+		res    *ResourceDefinition
+	}
+
+	children := make([]child, 0, len(n.folders)+len(n.resources))
+	for seg, f := range n.folders {
+		children = append(children, child{key: seg, folder: f})
+	}
+	for i := range n.resources {
+		r := &n.resources[i]
+		children = append(children, child{key: r.URI[strings.LastIndex(r.URI, "/")+1:], res: r})
+	}
+	sort.SliceStable(children, func(i, j int) bool {
+		if children[i].key != children[j].key {
+			return children[i].key < children[j].key
+		}
+		// A folder comes before a resource of the same name
+		return children[i].folder != nil && children[j].folder == nil
+	})
+
+	for _, c := range children {
+		if c.res != nil {
+			*entries = append(*entries, BrowseEntry{
+				Level:       level,
+				Name:        c.res.Name,
+				URI:         c.res.URI,
+				Description: c.res.Description,
+			})
+			continue
+		}
+
+		*entries = append(*entries, BrowseEntry{
+			Level:    level,
+			Name:     c.key + "/",
+			URI:      c.folder.uri,
+			IsFolder: true,
+			Count:    c.folder.count,
+		})
+		if maxDepth <= 0 || level+1 < maxDepth {
+			c.folder.appendEntries(entries, level+1, maxDepth)
+		}
+	}
+}
+
+// relativeURI returns the path of uri below prefix. An empty prefix matches
+// every URI and is relative to its scheme. A prefix that does not end with a
+// slash only matches whole path segments, so acdc://guides matches
+// acdc://guides/setup but not acdc://guidelines.
+func relativeURI(uri, prefix string) (string, bool) {
+	if prefix == "" {
+		if i := strings.Index(uri, "://"); i >= 0 {
+			return uri[i+3:], true
+		}
+		return uri, true
+	}
+	if !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	rel, ok := strings.CutPrefix(uri, prefix)
+	return rel, ok && rel != ""
+}
@@ -0,0 +1,64 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newBrowseProvider() *ResourceProvider {
+	return NewResourceProvider([]ResourceDefinition{
+		{URI: "acdc://readme", Name: "Readme", Description: "Start here"},
+		{URI: "acdc://guides/setup", Name: "Setup", Description: "Install the tools"},
+		{URI: "acdc://guides/api/auth", Name: "Auth", Description: "Authentication"},
+		{URI: "acdc://guides/api/errors", Name: "Errors"},
+		{URI: "acdc://guidelines/style", Name: "Style"},
+	})
+}
+
+func TestBrowse_FullTree(t *testing.T) {
+	entries := newBrowseProvider().Browse("", 0)
+
+	assert.Equal(t, []BrowseEntry{
+		{Level: 0, Name: "guidelines/", URI: "acdc://guidelines/", IsFolder: true, Count: 1},
+		{Level: 1, Name: "Style", URI: "acdc://guidelines/style"},
+		{Level: 0, Name: "guides/", URI: "acdc://guides/", IsFolder: true, Count: 3},
+		{Level: 1, Name: "api/", URI: "acdc://guides/api/", IsFolder: true, Count: 2},
+		{Level: 2, Name: "Auth", URI: "acdc://guides/api/auth", Description: "Authentication"},
+		{Level: 2, Name: "Errors", URI: "acdc://guides/api/errors"},
+		{Level: 1, Name: "Setup", URI: "acdc://guides/setup", Description: "Install the tools"},
+		{Level: 0, Name: "Readme", URI: "acdc://readme", Description: "Start here"},
+	}, entries)
+}
+
+func TestBrowse_Prefix(t *testing.T) {
+	p := newBrowseProvider()
+
+	expected := []BrowseEntry{
+		{Level: 0, Name: "api/", URI: "acdc://guides/api/", IsFolder: true, Count: 2},
+		{Level: 1, Name: "Auth", URI: "acdc://guides/api/auth", Description: "Authentication"},
+		{Level: 1, Name: "Errors", URI: "acdc://guides/api/errors"},
+		{Level: 0, Name: "Setup", URI: "acdc://guides/setup", Description: "Install the tools"},
+	}
+	assert.Equal(t, expected, p.Browse("acdc://guides/", 0))
+	assert.Equal(t, expected, p.Browse("acdc://guides", 0), "prefix without a trailing slash matches whole segments")
+
+	assert.Empty(t, p.Browse("acdc://guide", 0))
+	assert.Empty(t, p.Browse("acdc://readme", 0), "a resource URI is not a folder")
+}
+
+func TestBrowse_Depth(t *testing.T) {
+	p := newBrowseProvider()
+
+	assert.Equal(t, []BrowseEntry{
+		{Level: 0, Name: "guidelines/", URI: "acdc://guidelines/", IsFolder: true, Count: 1},
+		{Level: 0, Name: "guides/", URI: "acdc://guides/", IsFolder: true, Count: 3},
+		{Level: 0, Name: "Readme", URI: "acdc://readme", Description: "Start here"},
+	}, p.Browse("", 1))
+
+	entries := p.Browse("", 2)
+	assert.Len(t, entries, 6)
+	for _, e := range entries {
+		assert.Less(t, e.Level, 2)
+	}
+}
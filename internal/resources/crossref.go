@@ -1,9 +1,14 @@
 package resources
 
 import (
+	"fmt"
+	"net/url"
 	"path/filepath"
 	"regexp"
 	"strings"
+
+	"github.com/sha1n/mcp-acdc-server/internal/content"
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
 )
 
 // markdownLinkRe matches markdown links including images: ![text](target) and [text](target "title")
@@ -14,20 +19,129 @@ import (
 //   - Group 3: optional title with leading space (e.g. ` "Title"`)
 var markdownLinkRe = regexp.MustCompile(`!?\[([^\]]*)\]\(([^)\s]+)(\s+"[^"]*")?\)`)
 
-// NewCrossRefTransformer creates a ContentTransformer that rewrites relative
-// markdown links to MCP resource URIs. The scheme parameter is used to
-// recognize and skip links that already use the configured URI scheme.
-func NewCrossRefTransformer(definitions []ResourceDefinition, scheme string) ContentTransformer {
-	filePathToURI := make(map[string]string, len(definitions))
+// brokenLinkMarker is the format of the text that replaces links that do not
+// resolve when broken link markers are enabled, with the link text and target
+const brokenLinkMarker = "%s [broken link: %s]"
+
+// crossRefResolver resolves relative markdown link targets to resource URIs
+type crossRefResolver struct {
+	byPath       map[string]ResourceDefinition // File path to resource
+	schemePrefix string
+	markBroken   bool
+	cp           *content.ContentProvider
+	anchors      map[string]map[string]bool // File path to heading anchors, loaded on first use
+	includes     *includeResolver           // Expands includes of loaded files, if set
+}
+
+func newCrossRefResolver(definitions []ResourceDefinition, scheme string) *crossRefResolver {
+	byPath := make(map[string]ResourceDefinition, len(definitions))
 	for _, d := range definitions {
-		filePathToURI[d.FilePath] = d.URI
+		byPath[d.FilePath] = d
+	}
+	return &crossRefResolver{
+		byPath:       byPath,
+		schemePrefix: scheme + "://",
+		cp:           content.NewContentProvider(""),
+		anchors:      make(map[string]map[string]bool),
+	}
+}
+
+// resolve resolves a link target found in the file at currentPath. It returns
+// the target resource and fragment (including '#'). isRelative is false for
+// links that are not cross-references (fragment-only links, URLs and other
+// schemes); ok is false for cross-references that do not resolve.
+func (r *crossRefResolver) resolve(target, currentPath string) (defn ResourceDefinition, fragment string, isRelative, ok bool) {
+	// Skip fragment-only links
+	if strings.HasPrefix(target, "#") {
+		return ResourceDefinition{}, "", false, false
 	}
 
-	schemePrefix := scheme + "://"
+	// Skip links that already use the configured scheme or any other scheme
+	if strings.HasPrefix(target, r.schemePrefix) || strings.Contains(target, "://") {
+		return ResourceDefinition{}, "", false, false
+	}
 
-	return func(content string, currentDef ResourceDefinition) string {
-		currentDir := filepath.Dir(currentDef.FilePath)
+	// Skip mailto: and other colon-prefixed schemes
+	if strings.Contains(target, ":") {
+		return ResourceDefinition{}, "", false, false
+	}
+
+	// Separate path from fragment
+	if idx := strings.Index(target, "#"); idx >= 0 {
+		fragment = target[idx:]
+		target = target[:idx]
+	}
+
+	// Resolve relative path against current document's directory
+	resolved := filepath.Clean(filepath.Join(filepath.Dir(currentPath), target))
+
+	// Look up in the file path to resource map
+	defn, ok = r.byPath[resolved]
+	return defn, fragment, true, ok
+}
+
+// hasAnchor reports whether the fragment (including '#') names a heading of
+// the markdown file at path. Fragments are matched case-insensitively.
+func (r *crossRefResolver) hasAnchor(path, fragment string) bool {
+	anchors, ok := r.anchors[path]
+	if !ok {
+		if text, err := r.load(path); err == nil {
+			anchors = content.HeadingAnchors(text)
+		}
+		r.anchors[path] = anchors
+	}
+	return anchors[normalizeFragment(fragment)]
+}
+
+// load returns the content of the markdown file at path, without frontmatter
+// and with its includes expanded if the resolver has an include resolver.
+// Includes that cannot be resolved are left as-is; FindUnresolvedIncludes
+// reports them.
+func (r *crossRefResolver) load(path string) (string, error) {
+	md, err := r.cp.LoadMarkdownWithFrontmatter(path)
+	if err != nil {
+		return "", err
+	}
+	if r.includes == nil {
+		return md.Content, nil
+	}
+	text, _ := r.includes.expand(md.Content, path, []string{path})
+	return text, nil
+}
+
+// normalizeFragment returns the anchor named by a link fragment
+func normalizeFragment(fragment string) string {
+	anchor := strings.TrimPrefix(fragment, "#")
+	if unescaped, err := url.PathUnescape(anchor); err == nil {
+		anchor = unescaped
+	}
+	return strings.ToLower(anchor)
+}
+
+// CrossRefOption configures the transformer created by NewCrossRefTransformer
+type CrossRefOption func(*crossRefResolver)
+
+// WithBrokenLinkMarkers replaces relative links that do not resolve to a
+// resource with their text followed by a visible "[broken link: target]"
+// marker, instead of leaving them untouched
+func WithBrokenLinkMarkers(enabled bool) CrossRefOption {
+	return func(r *crossRefResolver) {
+		r.markBroken = enabled
+	}
+}
+
+// NewCrossRefTransformer creates a ContentTransformer that rewrites relative
+// markdown links to MCP resource URIs. The scheme parameter is used to
+// recognize and skip links that already use the configured URI scheme. Links
+// that do not resolve are left untouched unless broken link markers are
+// enabled; FindUnresolvedLinks reports them.
+func NewCrossRefTransformer(definitions []ResourceDefinition, scheme string, opts ...CrossRefOption) ContentTransformer {
+	resolver := newCrossRefResolver(definitions, scheme)
+	for _, opt := range opts {
+		opt(resolver)
+	}
 
+	return func(content string, currentDef ResourceDefinition) string {
 		return markdownLinkRe.ReplaceAllStringFunc(content, func(match string) string {
 			// Skip image links (starting with '!')
 			if strings.HasPrefix(match, "!") {
@@ -36,37 +150,13 @@ func NewCrossRefTransformer(definitions []ResourceDefinition, scheme string) Con
 
 			groups := markdownLinkRe.FindStringSubmatch(match)
 			linkText := groups[1]
-			target := groups[2]
 			title := groups[3] // includes leading space, e.g. ` "Title"`
 
-			// Skip fragment-only links
-			if strings.HasPrefix(target, "#") {
-				return match
-			}
-
-			// Skip links that already use the configured scheme or any other scheme
-			if strings.HasPrefix(target, schemePrefix) || strings.Contains(target, "://") {
-				return match
-			}
-
-			// Skip mailto: and other colon-prefixed schemes
-			if strings.Contains(target, ":") {
-				return match
-			}
-
-			// Separate path from fragment
-			fragment := ""
-			if idx := strings.Index(target, "#"); idx >= 0 {
-				fragment = target[idx:]
-				target = target[:idx]
-			}
-
-			// Resolve relative path against current document's directory
-			resolved := filepath.Clean(filepath.Join(currentDir, target))
-
-			// Look up in the file path to URI map
-			uri, ok := filePathToURI[resolved]
+			defn, fragment, isRelative, ok := resolver.resolve(groups[2], currentDef.FilePath)
 			if !ok {
+				if isRelative && resolver.markBroken {
+					return fmt.Sprintf(brokenLinkMarker, linkText, groups[2])
+				}
 				return match
 			}
 
@@ -75,7 +165,7 @@ func NewCrossRefTransformer(definitions []ResourceDefinition, scheme string) Con
 			b.WriteString("[")
 			b.WriteString(linkText)
 			b.WriteString("](")
-			b.WriteString(uri)
+			b.WriteString(defn.URI)
 			b.WriteString(fragment)
 			b.WriteString(title)
 			b.WriteString(")")
@@ -84,3 +174,50 @@ func NewCrossRefTransformer(definitions []ResourceDefinition, scheme string) Con
 		})
 	}
 }
+
+// FindUnresolvedLinks reports every relative markdown link in the given
+// markdown resources that does not resolve to a known resource as an error,
+// and every link whose fragment does not match a heading of the linked
+// markdown resource (or of the current resource, for fragment-only links) as
+// a warning. Image links are not cross-references and are ignored. Includes
+// are expanded first, so links in included content are checked and headings
+// of included sections are anchors of the including resource.
+func FindUnresolvedLinks(definitions []ResourceDefinition, scheme, resourcesDir string) []domain.Issue {
+	resolver := newCrossRefResolver(definitions, scheme)
+	resolver.includes = newIncludeResolver(resourcesDir)
+
+	var issues []domain.Issue
+	for _, defn := range definitions {
+		if !defn.isMarkdown() {
+			continue
+		}
+		text, err := resolver.load(defn.FilePath)
+		if err != nil {
+			issues = append(issues, domain.NewError(domain.RuleContentReadError, defn.FilePath, "%v", err))
+			continue
+		}
+		resolver.anchors[defn.FilePath] = content.HeadingAnchors(text)
+
+		for _, groups := range markdownLinkRe.FindAllStringSubmatch(text, -1) {
+			if strings.HasPrefix(groups[0], "!") {
+				continue
+			}
+			target := groups[2]
+			if strings.HasPrefix(target, "#") {
+				if len(target) > 1 && !resolver.hasAnchor(defn.FilePath, target) {
+					issues = append(issues, domain.NewWarning(domain.RuleMissingAnchor, defn.FilePath, "link %q does not match a heading of this resource", target))
+				}
+				continue
+			}
+
+			linked, fragment, isRelative, ok := resolver.resolve(target, defn.FilePath)
+			switch {
+			case isRelative && !ok:
+				issues = append(issues, domain.NewError(domain.RuleUnresolvedLink, defn.FilePath, "link %q does not resolve to a resource", target))
+			case ok && len(fragment) > 1 && linked.isMarkdown() && !resolver.hasAnchor(linked.FilePath, fragment):
+				issues = append(issues, domain.NewWarning(domain.RuleMissingAnchor, defn.FilePath, "link %q does not match a heading of %s", target, linked.URI))
+			}
+		}
+	}
+	return issues
+}
@@ -1,8 +1,12 @@
 package resources
 
 import (
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
 )
 
 func TestCrossRefTransformer_BasicRelativeLink(t *testing.T) {
@@ -409,3 +413,108 @@ func TestCrossRefTransformer_ConsecutiveLinksOnSameLine(t *testing.T) {
 		t.Errorf("got %q, want %q", got, want)
 	}
 }
+
+func TestFindUnresolvedLinks(t *testing.T) {
+	tmp := t.TempDir()
+	write := func(name, body string) string {
+		path := filepath.Join(tmp, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	current := write("guides/current.md", "---\nname: C\ndescription: D\n---\n"+
+		"[ok](../other.md#section) [missing](missing.md) [frag](#local) "+
+		"[web](https://example.com/x.md) [mail](mailto:a@b.c) ![img](diagram.png) [uri](acdc://other)\n\n## Local\n")
+	other := write("other.md", "---\nname: O\ndescription: D\n---\n## Section\n\nBack to [current](guides/current.md)")
+
+	defs := []ResourceDefinition{
+		{URI: "acdc://guides/current", FilePath: current},
+		{URI: "acdc://other", FilePath: other},
+		{URI: "acdc://gone", FilePath: filepath.Join(tmp, "gone.md")},
+	}
+
+	issues := FindUnresolvedLinks(defs, "acdc", tmp)
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %+v", issues)
+	}
+	if issues[0].Rule != domain.RuleUnresolvedLink || issues[0].File != current || !strings.Contains(issues[0].Message, "missing.md") {
+		t.Errorf("Unexpected unresolved link issue: %+v", issues[0])
+	}
+	if issues[1].Rule != domain.RuleContentReadError {
+		t.Errorf("Expected read error for missing file, got %+v", issues[1])
+	}
+}
+
+func TestFindUnresolvedLinks_MissingAnchors(t *testing.T) {
+	tmp := writeResources(t, map[string]string{
+		"guide.md": "---\nname: G\ndescription: D\n---\n# Guide\n\n## Rolling Back\n\n" +
+			"[ok](#rolling-back) [case](other.md#Step-1) [bad](#roll-back) [other](other.md#nope) [json](data.json#x)\n\n" +
+			"```\n## Not A Heading\n```\n[fence](#not-a-heading)",
+		"other.md": "---\nname: O\ndescription: D\n---\n## Step 1\n",
+	})
+	resDir := filepath.Join(tmp, "mcp-resources")
+	guide := filepath.Join(resDir, "guide.md")
+	defs := []ResourceDefinition{
+		{URI: "acdc://guide", MIMEType: MIMETypeMarkdown, FilePath: guide},
+		{URI: "acdc://other", MIMEType: MIMETypeMarkdown, FilePath: filepath.Join(resDir, "other.md")},
+		{URI: "acdc://data.json", MIMEType: "application/json", FilePath: filepath.Join(resDir, "data.json")},
+	}
+
+	issues := FindUnresolvedLinks(defs, "acdc", resDir)
+	if len(issues) != 3 {
+		t.Fatalf("Expected 3 issues, got %+v", issues)
+	}
+	for i, target := range []string{"#roll-back", "other.md#nope", "#not-a-heading"} {
+		issue := issues[i]
+		if issue.Rule != domain.RuleMissingAnchor || issue.Severity != domain.SeverityWarning || issue.File != guide || !strings.Contains(issue.Message, target) {
+			t.Errorf("Unexpected issue %d: %+v", i, issue)
+		}
+	}
+}
+
+func TestCrossRefTransformer_BrokenLinkMarkers(t *testing.T) {
+	defs := []ResourceDefinition{
+		{URI: "acdc://guides/current", FilePath: "/res/guides/current.md"},
+		{URI: "acdc://other", FilePath: "/res/other.md"},
+	}
+	input := "[ok](../other.md) [missing](missing.md#x) [web](https://example.com) [frag](#y)"
+
+	unmarked := NewCrossRefTransformer(defs, "acdc")(input, defs[0])
+	if unmarked != "[ok](acdc://other) [missing](missing.md#x) [web](https://example.com) [frag](#y)" {
+		t.Errorf("Unexpected output without markers: %q", unmarked)
+	}
+
+	marked := NewCrossRefTransformer(defs, "acdc", WithBrokenLinkMarkers(true))(input, defs[0])
+	if marked != "[ok](acdc://other) missing [broken link: missing.md#x] [web](https://example.com) [frag](#y)" {
+		t.Errorf("Unexpected output with markers: %q", marked)
+	}
+}
+
+func TestFindUnresolvedLinks_Includes(t *testing.T) {
+	tmp := writeResources(t, map[string]string{
+		"guide.md": "---\nname: G\ndescription: D\n---\n# Guide\n\n{{< include \"_includes/steps.md\" >}}\n\n" +
+			"[local](#rollback) [other](other.md#contacts)",
+		"other.md":              "---\nname: O\ndescription: D\n---\n{{< include \"_includes/contacts.md\" >}}\n",
+		"_includes/steps.md":    "## Rollback\n\nSee [the runbook](../runbook.md).\n",
+		"_includes/contacts.md": "## Contacts\n\nops@example.com\n",
+	})
+	resDir := filepath.Join(tmp, "mcp-resources")
+	guide := filepath.Join(resDir, "guide.md")
+	defs := []ResourceDefinition{
+		{URI: "acdc://guide", MIMEType: MIMETypeMarkdown, FilePath: guide},
+		{URI: "acdc://other", MIMEType: MIMETypeMarkdown, FilePath: filepath.Join(resDir, "other.md")},
+	}
+
+	issues := FindUnresolvedLinks(defs, "acdc", resDir)
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %+v", issues)
+	}
+	if issues[0].Rule != domain.RuleUnresolvedLink || issues[0].File != guide || !strings.Contains(issues[0].Message, "runbook.md") {
+		t.Errorf("Expected unresolved link from the included snippet, got %+v", issues[0])
+	}
+}
@@ -1,5 +1,7 @@
 package resources
 
+import "time"
+
 // Field name constants for resource metadata
 const (
 	FieldURI      = "uri"
@@ -16,4 +18,18 @@ type ResourceDefinition struct {
 	MIMEType    string
 	FilePath    string
 	Keywords    []string // Optional keywords for search boosting
+	Priority    float64  // Optional ranking priority (0 is neutral)
+	Updated     *time.Time
+	Deprecated  bool
+	Aliases     []string // Former URIs that resolve to this resource
+
+	// MCP annotations
+	Audience     []string   // Intended readers: "user" and/or "assistant"
+	Importance   *float64   // Annotation priority from 0 (optional) to 1 (required)
+	LastModified *time.Time // Last commit or modification time of the file
+	Size         int64      // Size of the served content in bytes, excluding frontmatter
+
+	// Attachments
+	Attachments []string // URIs of the attachments of a markdown resource
+	AttachedTo  string   // URI of the markdown resource an attachment belongs to
 }
@@ -0,0 +1,353 @@
+package resources
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/zlib"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MIME types of attachments with a built-in text extractor
+const (
+	MIMETypePDF  = "application/pdf"
+	MIMETypeDOCX = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	MIMETypeHTML = "text/html"
+)
+
+// errNoExtractor is returned when text is requested from an attachment whose
+// MIME type has no extractor
+var errNoExtractor = errors.New("no text extractor")
+
+// Extractor extracts plain text from the content of a binary file, for search
+// indexing and for tools that return text
+type Extractor interface {
+	Extract(data []byte) (string, error)
+}
+
+// ExtractorFunc adapts a function to the Extractor interface
+type ExtractorFunc func(data []byte) (string, error)
+
+// Extract calls f(data)
+func (f ExtractorFunc) Extract(data []byte) (string, error) {
+	return f(data)
+}
+
+// WithExtractor registers the text extractor of attachments of the given MIME
+// type, replacing the built-in extractor of that type, if any.
+func WithExtractor(mimeType string, e Extractor) Option {
+	return func(p *ResourceProvider) {
+		p.extractors[mimeType] = e
+	}
+}
+
+// defaultExtractors returns the built-in extractors keyed by MIME type
+func defaultExtractors() map[string]Extractor {
+	return map[string]Extractor{
+		MIMETypePDF:  ExtractorFunc(extractPDF),
+		MIMETypeDOCX: ExtractorFunc(extractDOCX),
+		MIMETypeHTML: ExtractorFunc(extractHTML),
+	}
+}
+
+// extractText returns the text of an attachment. Attachments of a text type
+// without an extractor are returned as-is.
+func extractText(extractors map[string]Extractor, mimeType string, data []byte) (string, error) {
+	if e, ok := extractors[mimeType]; ok {
+		return e.Extract(data)
+	}
+	if isTextMIMEType(mimeType) {
+		return string(data), nil
+	}
+	return "", fmt.Errorf("%w for %s", errNoExtractor, mimeType)
+}
+
+var (
+	htmlHiddenRe   = regexp.MustCompile(`(?is)<(?:script|style|head)\b.*?</(?:script|style|head)\s*>|<!--.*?-->`)
+	htmlBlockEndRe = regexp.MustCompile(`(?i)<br\s*/?>|</(?:p|div|li|tr|h[1-6]|pre|blockquote|section|article|table)\s*>`)
+	htmlTagRe      = regexp.MustCompile(`<[^>]*>`)
+)
+
+// extractHTML strips scripts, styles and tags from an HTML document, keeping a
+// line break after every block element
+func extractHTML(data []byte) (string, error) {
+	text := htmlHiddenRe.ReplaceAllString(string(data), "")
+	text = htmlBlockEndRe.ReplaceAllString(text, "\n")
+	text = htmlTagRe.ReplaceAllString(text, "")
+	return normalizeLines(html.UnescapeString(text)), nil
+}
+
+// extractDOCX returns the text of the paragraphs of a Word document, one line
+// per paragraph
+func extractDOCX(data []byte) (string, error) {
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", fmt.Errorf("invalid DOCX file: %w", err)
+	}
+	f, err := r.Open("word/document.xml")
+	if err != nil {
+		return "", fmt.Errorf("invalid DOCX file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var sb strings.Builder
+	decoder := xml.NewDecoder(f)
+	inText := false
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("invalid DOCX document: %w", err)
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "t":
+				inText = true
+			case "tab":
+				sb.WriteString("\t")
+			case "br", "cr":
+				sb.WriteString("\n")
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				sb.WriteString("\n")
+			}
+		case xml.CharData:
+			if inText {
+				sb.Write(t)
+			}
+		}
+	}
+	return normalizeLines(sb.String()), nil
+}
+
+var pdfStreamRe = regexp.MustCompile(`(?s)stream\r?\n(.*?)\r?\nendstream`)
+
+// pdfSubtypeRe matches the subtype of a PDF stream dictionary. Group 1 is the
+// subtype name.
+var pdfSubtypeRe = regexp.MustCompile(`/Subtype\s*/(\w+)`)
+
+const (
+	// maxUndecodablePDFText is the fraction of non-printable characters above
+	// which the text of a PDF is considered undecodable
+	maxUndecodablePDFText = 0.1
+	// maxPDFStreamSize limits the inflated size of a PDF stream; the rest of
+	// larger streams is ignored
+	maxPDFStreamSize = 8 << 20
+)
+
+// extractPDF returns the text shown by the content streams of a PDF document.
+// It is a best-effort extractor: compressed streams other than Flate and fonts
+// with custom encodings are not decoded. Strings of fonts with custom
+// encodings, such as the glyph IDs of Identity-H CID fonts used by most
+// generated PDFs, do not decode to text; such documents are reported as
+// having no extractor rather than yielding garbage.
+func extractPDF(data []byte) (string, error) {
+	if !bytes.HasPrefix(data, []byte("%PDF-")) {
+		return "", errors.New("invalid PDF file: missing %PDF header")
+	}
+
+	var sb strings.Builder
+	for _, m := range pdfStreamRe.FindAllSubmatchIndex(data, -1) {
+		if !isPDFContentStream(pdfStreamDict(data, m[0])) {
+			continue
+		}
+		stream := data[m[2]:m[3]]
+		if r, err := zlib.NewReader(bytes.NewReader(stream)); err == nil {
+			if inflated, err := io.ReadAll(io.LimitReader(r, maxPDFStreamSize)); err == nil {
+				stream = inflated
+			}
+		}
+		pdfContentText(&sb, stream)
+	}
+	text := normalizeLines(sb.String())
+	if !isPrintableText(text, maxUndecodablePDFText) {
+		return "", fmt.Errorf("%w: PDF text uses an unsupported font encoding", errNoExtractor)
+	}
+	return text, nil
+}
+
+// pdfStreamDict returns the dictionary of the stream starting at offset
+// start: the data between the start of the enclosing object and the stream
+func pdfStreamDict(data []byte, start int) []byte {
+	dict := data[:start]
+	if i := bytes.LastIndex(dict, []byte("obj")); i >= 0 {
+		dict = dict[i:]
+	}
+	return dict
+}
+
+// isPDFContentStream reports whether a stream with the given dictionary may
+// show text: page content and form XObjects, but not images, embedded font
+// files, metadata, cross-reference or object streams
+func isPDFContentStream(dict []byte) bool {
+	if m := pdfSubtypeRe.FindSubmatch(dict); m != nil && string(m[1]) != "Form" {
+		return false
+	}
+	for _, key := range []string{"/Length1", "/Type /XRef", "/Type/XRef", "/Type /ObjStm", "/Type/ObjStm"} {
+		if bytes.Contains(dict, []byte(key)) {
+			return false
+		}
+	}
+	return true
+}
+
+// isPrintableText reports whether at most maxNonPrintable of the characters
+// of text are invalid UTF-8 or non-printable
+func isPrintableText(text string, maxNonPrintable float64) bool {
+	total, bad := 0, 0
+	for _, r := range text {
+		total++
+		if r == utf8.RuneError || (!unicode.IsPrint(r) && !unicode.IsSpace(r)) {
+			bad++
+		}
+	}
+	return float64(bad) <= maxNonPrintable*float64(total)
+}
+
+// pdfContentText writes the strings shown by the text operators of a PDF
+// content stream, with line breaks at line moves and the end of text objects
+func pdfContentText(sb *strings.Builder, stream []byte) {
+	var operands []string
+	for i := 0; i < len(stream); {
+		c := stream[i]
+		switch {
+		case c == '(':
+			s, next := pdfLiteralString(stream, i+1)
+			operands = append(operands, s)
+			i = next
+		case bytes.HasPrefix(stream[i:], []byte("<<")), bytes.HasPrefix(stream[i:], []byte(">>")):
+			// Dictionary delimiters
+			i += 2
+		case c == '<':
+			end := bytes.IndexByte(stream[i:], '>')
+			if end < 0 {
+				return
+			}
+			hexText := strings.Join(strings.Fields(string(stream[i+1:i+end])), "")
+			if len(hexText)%2 == 1 {
+				hexText += "0"
+			}
+			if decoded, err := hex.DecodeString(hexText); err == nil {
+				operands = append(operands, string(decoded))
+			}
+			i += end + 1
+		case c == '%':
+			for i < len(stream) && stream[i] != '\n' && stream[i] != '\r' {
+				i++
+			}
+		case isPDFDelimiter(c) || isPDFSpace(c):
+			i++
+		default:
+			start := i
+			for i < len(stream) && !isPDFDelimiter(stream[i]) && !isPDFSpace(stream[i]) {
+				i++
+			}
+			token := string(stream[start:i])
+			if n, err := strconv.ParseFloat(token, 64); err == nil {
+				// Large negative kerning in TJ arrays separates words
+				if n <= -200 && len(operands) > 0 {
+					operands = append(operands, " ")
+				}
+				continue
+			}
+			switch token {
+			case "Tj", "TJ":
+				sb.WriteString(strings.Join(operands, ""))
+			case "'", `"`:
+				sb.WriteString("\n")
+				sb.WriteString(strings.Join(operands, ""))
+			case "Td", "TD", "T*", "ET":
+				sb.WriteString("\n")
+			}
+			operands = operands[:0]
+		}
+	}
+}
+
+// pdfLiteralString parses a PDF literal string starting after its opening
+// parenthesis. It returns the string and the index after its closing
+// parenthesis.
+func pdfLiteralString(stream []byte, i int) (string, int) {
+	var sb strings.Builder
+	depth := 1
+	for ; i < len(stream); i++ {
+		c := stream[i]
+		switch c {
+		case '\\':
+			i++
+			if i >= len(stream) {
+				return sb.String(), i
+			}
+			switch e := stream[i]; e {
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			case 'b', 'f':
+			case '\r', '\n':
+				// Line continuation
+			default:
+				if e >= '0' && e <= '7' {
+					n := 0
+					for j := 0; j < 3 && i < len(stream) && stream[i] >= '0' && stream[i] <= '7'; j++ {
+						n = n*8 + int(stream[i]-'0')
+						i++
+					}
+					i--
+					sb.WriteByte(byte(n))
+				} else {
+					sb.WriteByte(e)
+				}
+			}
+		case '(':
+			depth++
+			sb.WriteByte(c)
+		case ')':
+			depth--
+			if depth == 0 {
+				return sb.String(), i + 1
+			}
+			sb.WriteByte(c)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String(), i
+}
+
+func isPDFDelimiter(c byte) bool {
+	return strings.IndexByte("()<>[]{}/%", c) >= 0
+}
+
+func isPDFSpace(c byte) bool {
+	return strings.IndexByte(" \t\r\n\f\x00", c) >= 0
+}
+
+// normalizeLines trims every line and drops empty lines
+func normalizeLines(text string) string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
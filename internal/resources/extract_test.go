@@ -0,0 +1,137 @@
+package resources
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/zlib"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractHTML(t *testing.T) {
+	doc := `<html><head><title>Ignored</title><style>p {}</style></head>
+<body><h1>Escalation</h1><script>alert(1)</script><!-- hidden -->
+<p>Page the <b>on-call</b> &amp; lead.</p><ul><li>One</li><li>Two</li></ul></body></html>`
+
+	text, err := extractHTML([]byte(doc))
+	require.NoError(t, err)
+	assert.Equal(t, "Escalation\nPage the on-call & lead.\nOne\nTwo", text)
+}
+
+func TestExtractDOCX(t *testing.T) {
+	t.Run("Paragraphs", func(t *testing.T) {
+		data := docxFile(t, `<?xml version="1.0" encoding="UTF-8"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>
+<w:p><w:r><w:t>Incident</w:t></w:r><w:r><w:t xml:space="preserve"> handbook</w:t></w:r></w:p>
+<w:p><w:r><w:t>Call</w:t><w:tab/><w:t>555</w:t></w:r></w:p>
+</w:body></w:document>`)
+
+		text, err := extractDOCX(data)
+		require.NoError(t, err)
+		assert.Equal(t, "Incident handbook\nCall 555", text)
+	})
+
+	t.Run("NotAZip", func(t *testing.T) {
+		_, err := extractDOCX([]byte("plain text"))
+		assert.Error(t, err)
+	})
+}
+
+func TestExtractPDF(t *testing.T) {
+	t.Run("PlainAndFlateStreams", func(t *testing.T) {
+		var compressed bytes.Buffer
+		w := zlib.NewWriter(&compressed)
+		_, _ = w.Write([]byte("BT /F1 12 Tf 72 700 Td [(Second) -250 (page)] TJ ET"))
+		_ = w.Close()
+
+		var pdf bytes.Buffer
+		pdf.WriteString("%PDF-1.4\n1 0 obj\n<< /Length 60 >>\nstream\n")
+		pdf.WriteString("BT /F1 12 Tf 72 712 Td (Runbook \\(v2\\)) Tj T* <48656c6c6f> Tj ET")
+		pdf.WriteString("\nendstream\nendobj\n2 0 obj\n<< /Filter /FlateDecode >>\nstream\n")
+		pdf.Write(compressed.Bytes())
+		pdf.WriteString("\nendstream\nendobj\n%%EOF\n")
+
+		text, err := extractPDF(pdf.Bytes())
+		require.NoError(t, err)
+		assert.Equal(t, "Runbook (v2)\nHello\nSecond page", text)
+	})
+
+	t.Run("SkipsImagesAndFonts", func(t *testing.T) {
+		var pdf bytes.Buffer
+		pdf.WriteString("%PDF-1.4\n1 0 obj\n<< /Type /XObject /Subtype /Image /Width 1 >>\nstream\n(Image) Tj")
+		pdf.WriteString("\nendstream\nendobj\n2 0 obj\n<< /Length 10 /Length1 20 >>\nstream\n(Font) Tj")
+		pdf.WriteString("\nendstream\nendobj\n3 0 obj\n<< /Subtype/Type1C >>\nstream\n(CFF) Tj")
+		pdf.WriteString("\nendstream\nendobj\n4 0 obj\n<< /Type /XObject /Subtype /Form >>\nstream\nBT (Form) Tj ET")
+		pdf.WriteString("\nendstream\nendobj\n%%EOF\n")
+
+		text, err := extractPDF(pdf.Bytes())
+		require.NoError(t, err)
+		assert.Equal(t, "Form", text)
+	})
+
+	t.Run("LimitsInflatedSize", func(t *testing.T) {
+		var compressed bytes.Buffer
+		w := zlib.NewWriter(&compressed)
+		_, _ = w.Write([]byte("BT (Start) Tj ET "))
+		_, _ = w.Write(bytes.Repeat([]byte(" "), maxPDFStreamSize))
+		_, _ = w.Write([]byte("BT (End) Tj ET"))
+		_ = w.Close()
+
+		var pdf bytes.Buffer
+		pdf.WriteString("%PDF-1.4\n1 0 obj\n<< /Filter /FlateDecode >>\nstream\n")
+		pdf.Write(compressed.Bytes())
+		pdf.WriteString("\nendstream\nendobj\n%%EOF\n")
+
+		text, err := extractPDF(pdf.Bytes())
+		require.NoError(t, err)
+		assert.Equal(t, "Start", text)
+	})
+
+	t.Run("NotAPDF", func(t *testing.T) {
+		_, err := extractPDF([]byte("plain text"))
+		assert.Error(t, err)
+	})
+
+	t.Run("CIDFontGlyphIDs", func(t *testing.T) {
+		// A Flate-compressed page shown with an Identity-H encoded TrueType
+		// font: its strings are glyph IDs, not text
+		data, err := os.ReadFile(filepath.Join("testdata", "identity-h.pdf"))
+		require.NoError(t, err)
+
+		_, err = extractPDF(data)
+		assert.ErrorIs(t, err, errNoExtractor)
+	})
+}
+
+func TestExtractText(t *testing.T) {
+	extractors := defaultExtractors()
+
+	text, err := extractText(extractors, "text/csv", []byte("a,b"))
+	require.NoError(t, err)
+	assert.Equal(t, "a,b", text)
+
+	_, err = extractText(extractors, "image/png", []byte{0x89, 'P', 'N', 'G'})
+	assert.ErrorIs(t, err, errNoExtractor)
+
+	extractors["image/png"] = ExtractorFunc(func(data []byte) (string, error) { return "OCR text", nil })
+	text, err = extractText(extractors, "image/png", []byte{0x89, 'P', 'N', 'G'})
+	require.NoError(t, err)
+	assert.Equal(t, "OCR text", text)
+}
+
+// docxFile returns a DOCX archive with the given document XML
+func docxFile(t *testing.T, documentXML string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	f, err := zw.Create("word/document.xml")
+	require.NoError(t, err)
+	_, err = f.Write([]byte(documentXML))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
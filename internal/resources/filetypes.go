@@ -0,0 +1,162 @@
+package resources
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MIME types of resources with format specific handling
+const (
+	MIMETypeMarkdown = "text/markdown"
+	MIMETypeJSON     = "application/json"
+	MIMETypeYAML     = "application/yaml"
+)
+
+// markdownExt is the extension of markdown resources, which carry their
+// metadata in frontmatter
+const markdownExt = ".md"
+
+// mimeTypes maps the extensions of supported non-markdown resources to their
+// MIME types. These resources are served as-is and need sidecar or manifest
+// metadata.
+var mimeTypes = map[string]string{
+	".json":    MIMETypeJSON,
+	".yaml":    MIMETypeYAML,
+	".yml":     MIMETypeYAML,
+	".txt":     "text/plain",
+	".csv":     "text/csv",
+	".xml":     "application/xml",
+	".toml":    "application/toml",
+	".graphql": "application/graphql",
+	".proto":   "text/x-protobuf",
+	".sql":     "application/sql",
+	".sh":      "application/x-sh",
+	".go":      "text/x-go",
+	".py":      "text/x-python",
+	".js":      "text/javascript",
+	".ts":      "text/x-typescript",
+	".java":    "text/x-java",
+	".kt":      "text/x-kotlin",
+	".rb":      "text/x-ruby",
+	".rs":      "text/x-rust",
+}
+
+// isMarkdown reports whether the resource is a markdown file with frontmatter.
+// Definitions without a MIME type are markdown.
+func (d ResourceDefinition) isMarkdown() bool {
+	return d.MIMEType == "" || d.MIMEType == MIMETypeMarkdown
+}
+
+// mimeTypeOf returns the MIME type of a supported non-markdown file
+func mimeTypeOf(path string) (string, bool) {
+	mimeType, ok := mimeTypes[strings.ToLower(filepath.Ext(path))]
+	return mimeType, ok
+}
+
+// indexText returns the text of a resource to index for search. JSON and YAML
+// documents are flattened to one "path: value" line per scalar so that keys
+// and values are searchable without syntax noise; other formats are indexed
+// as-is. Documents that fail to parse are indexed as-is.
+func indexText(mimeType, text string) string {
+	var docs []interface{}
+	var err error
+	switch mimeType {
+	case MIMETypeJSON:
+		docs, err = decodeJSON(text)
+	case MIMETypeYAML:
+		docs, err = decodeYAML(text)
+	default:
+		return text
+	}
+	if err != nil {
+		return text
+	}
+
+	var lines []string
+	for _, doc := range docs {
+		lines = flatten(lines, "", doc)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func decodeJSON(text string) ([]interface{}, error) {
+	var doc interface{}
+	if err := json.Unmarshal([]byte(text), &doc); err != nil {
+		return nil, err
+	}
+	return []interface{}{doc}, nil
+}
+
+func decodeYAML(text string) ([]interface{}, error) {
+	var docs []interface{}
+	decoder := yaml.NewDecoder(bytes.NewReader([]byte(text)))
+	for {
+		var doc interface{}
+		err := decoder.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			return docs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+}
+
+// flatten appends a "path: value" line for every scalar in value, with map
+// keys in sorted order
+func flatten(lines []string, path string, value interface{}) []string {
+	join := func(key string) string {
+		if path == "" {
+			return key
+		}
+		return path + "." + key
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			lines = flatten(lines, join(k), v[k])
+		}
+	case map[interface{}]interface{}:
+		keys := make([]string, 0, len(v))
+		values := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			key := fmt.Sprint(k)
+			keys = append(keys, key)
+			values[key] = val
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			lines = flatten(lines, join(k), values[k])
+		}
+	case []interface{}:
+		for i, item := range v {
+			lines = flatten(lines, fmt.Sprintf("%s[%d]", path, i), item)
+		}
+	case nil:
+		if path != "" {
+			lines = append(lines, path+":")
+		}
+	default:
+		if path == "" {
+			lines = append(lines, fmt.Sprint(v))
+		} else {
+			lines = append(lines, fmt.Sprintf("%s: %v", path, v))
+		}
+	}
+	return lines
+}
@@ -0,0 +1,121 @@
+package resources
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/sha1n/mcp-acdc-server/internal/content"
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexText(t *testing.T) {
+	tests := []struct {
+		name     string
+		mimeType string
+		text     string
+		expected string
+	}{
+		{
+			"JSON",
+			MIMETypeJSON,
+			`{"info": {"title": "Billing API", "version": 2}, "tags": [{"name": "invoices"}, "refunds"], "deprecated": null}`,
+			"deprecated:\ninfo.title: Billing API\ninfo.version: 2\ntags[0].name: invoices\ntags[1]: refunds",
+		},
+		{
+			"YAMLMultiDocument",
+			MIMETypeYAML,
+			"kind: Service\nspec:\n  port: 8080\n---\nkind: Deployment\n",
+			"kind: Service\nspec.port: 8080\nkind: Deployment",
+		},
+		{"InvalidJSON", MIMETypeJSON, `{"broken": `, `{"broken": `},
+		{"Code", "text/x-go", "package main\n\nfunc main() {}", "package main\n\nfunc main() {}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, indexText(tt.mimeType, tt.text))
+		})
+	}
+}
+
+func TestDiscoverResources_NonMarkdown(t *testing.T) {
+	tmp := t.TempDir()
+	resDir := filepath.Join(tmp, "mcp-resources")
+	files := map[string]string{
+		"guide.md":                       "---\nname: Guide\ndescription: A guide\n---\nGuide",
+		"specs/openapi.json":             `{"info": {"title": "Billing API"}}`,
+		"specs/openapi.json.meta.yaml":   "name: Billing API\ndescription: OpenAPI spec\nkeywords: [billing]\n",
+		"specs/schema.json":              `{"type": "object"}`,
+		"specs/_meta.yaml":               "schema.json:\n  name: Schema\n  description: Invoice schema\nopenapi.json:\n  name: Overridden\n  description: By the sidecar\ngone.json:\n  name: Gone\n  description: Missing\n",
+		"specs/unlisted.json":            `{}`,
+		"examples/config.yaml":           "port: 8080\n",
+		"examples/config.yaml.meta.yaml": "name: Config\n",
+		"examples/main.go":               "package main",
+		"examples/main.go.meta.yaml":     "name: Main\ndescription: Example program\n",
+		"examples/broken.txt":            "text",
+		"examples/broken.txt.meta.yaml":  "name: [unclosed\n",
+		"examples/logo.png.meta.yaml":    "name: Logo\ndescription: Logo\n",
+		"guide.md.meta.yaml":             "name: Guide\ndescription: Ignored\n",
+	}
+	for name, body := range files {
+		path := filepath.Join(resDir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(body), 0644))
+	}
+
+	defs, issues, err := DiscoverResourcesWithIssues(content.NewContentProvider(tmp), "acdc")
+	require.NoError(t, err)
+
+	byURI := make(map[string]ResourceDefinition)
+	for _, d := range defs {
+		byURI[d.URI] = d
+	}
+	uris := make([]string, 0, len(byURI))
+	for uri := range byURI {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+	assert.Equal(t, []string{"acdc://examples/main.go", "acdc://guide", "acdc://specs/openapi.json", "acdc://specs/schema.json"}, uris)
+
+	openapi := byURI["acdc://specs/openapi.json"]
+	assert.Equal(t, "Billing API", openapi.Name, "sidecar metadata takes precedence over the manifest")
+	assert.Equal(t, MIMETypeJSON, openapi.MIMEType)
+	assert.Equal(t, []string{"billing"}, openapi.Keywords)
+	assert.Equal(t, "Schema", byURI["acdc://specs/schema.json"].Name)
+	assert.Equal(t, "text/x-go", byURI["acdc://examples/main.go"].MIMEType)
+	assert.Equal(t, MIMETypeMarkdown, byURI["acdc://guide"].MIMEType)
+
+	messages := make(map[string]domain.Issue)
+	for _, issue := range issues {
+		rel, _ := filepath.Rel(resDir, issue.File)
+		messages[filepath.ToSlash(rel)] = issue
+	}
+	assert.Len(t, issues, 5)
+	assert.Equal(t, domain.RuleRequiredField, messages["examples/config.yaml"].Rule)
+	assert.Contains(t, messages["examples/config.yaml"].Message, "missing required sidecar or manifest field(s): description")
+	assert.Equal(t, domain.SeverityError, messages["examples/broken.txt.meta.yaml"].Severity)
+	assert.Contains(t, messages["examples/logo.png.meta.yaml"].Message, "not a supported resource type")
+	assert.Contains(t, messages["guide.md.meta.yaml"].Message, "use frontmatter instead")
+	assert.Contains(t, messages["specs/_meta.yaml"].Message, "metadata for gone.json is ignored")
+	assert.Equal(t, domain.SeverityWarning, messages["specs/_meta.yaml"].Severity)
+
+	provider := NewResourceProvider(defs, WithTransformer(func(c string, _ ResourceDefinition) string { return "transformed" }))
+	text, err := provider.ReadResource("acdc://specs/openapi.json")
+	require.NoError(t, err)
+	assert.Equal(t, `{"info": {"title": "Billing API"}}`, text, "non-markdown resources are returned as-is")
+
+	ch := make(chan domain.Document, len(defs))
+	require.NoError(t, NewResourceProvider(defs).StreamResources(context.Background(), ch))
+	close(ch)
+	indexed := make(map[string]string)
+	for doc := range ch {
+		indexed[doc.URI] = doc.Content
+	}
+	assert.Equal(t, "info.title: Billing API", indexed["acdc://specs/openapi.json"])
+	assert.Equal(t, "package main", indexed["acdc://examples/main.go"])
+}
@@ -0,0 +1,214 @@
+package resources
+
+import (
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/sha1n/mcp-acdc-server/internal/content"
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+)
+
+// includeDirectiveRe matches include directives: {{< include "path" >}} and
+// {{< include "path#Heading" >}}. Group 1 is the quoted target.
+var includeDirectiveRe = regexp.MustCompile(`\{\{<\s*include\s+"([^"]+)"\s*>\}\}`)
+
+const (
+	// includesDirName names directories of snippets that are only included
+	// by resources. They are not discovered as resources and need no
+	// frontmatter.
+	includesDirName = "_includes"
+	// maxIncludeDepth limits nested includes
+	maxIncludeDepth = 10
+)
+
+// includeResolver expands include directives in markdown content
+type includeResolver struct {
+	resourcesDir string
+	cp           *content.ContentProvider
+}
+
+func newIncludeResolver(resourcesDir string) *includeResolver {
+	return &includeResolver{resourcesDir: filepath.Clean(resourcesDir), cp: content.NewContentProvider("")}
+}
+
+// expand replaces the include directives in text, the content of the file at
+// path, with the content they include. Targets starting with '/' are relative
+// to the resources directory, others to the directory of path. A '#Heading'
+// suffix includes only that section. stack holds the files being expanded,
+// outermost first. Directives inside fenced code blocks are not expanded.
+// Directives that cannot be resolved are left as-is and reported as errors.
+func (r *includeResolver) expand(text, path string, stack []string) (string, []error) {
+	var errs []error
+	result := replaceOutsideFences(text, includeDirectiveRe, func(directive string) string {
+		target := includeDirectiveRe.FindStringSubmatch(directive)[1]
+		included, nestedErrs, err := r.include(target, path, stack)
+		errs = append(errs, nestedErrs...)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("include %q in %s: %w", target, r.relative(path), err))
+			return directive
+		}
+		return included
+	})
+	return result, errs
+}
+
+// replaceOutsideFences replaces the matches of re in text, except in fenced
+// code blocks, with the result of repl
+func replaceOutsideFences(text string, re *regexp.Regexp, repl func(string) string) string {
+	lines := strings.Split(text, "\n")
+	inFence := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if !inFence {
+			lines[i] = re.ReplaceAllStringFunc(line, repl)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// include returns the expanded content included by target from the file at
+// path. Errors of nested includes are returned separately from the error of
+// target itself.
+func (r *includeResolver) include(target, path string, stack []string) (string, []error, error) {
+	filePart, heading, _ := strings.Cut(target, "#")
+
+	var resolved string
+	if strings.HasPrefix(filePart, "/") {
+		resolved = filepath.Join(r.resourcesDir, filepath.FromSlash(filePart))
+	} else {
+		resolved = filepath.Join(filepath.Dir(path), filepath.FromSlash(filePart))
+	}
+	if rel, err := filepath.Rel(r.resourcesDir, resolved); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", nil, fmt.Errorf("path is outside of the resources directory")
+	}
+
+	for i, p := range stack {
+		if p == resolved {
+			chain := make([]string, 0, len(stack)-i+1)
+			for _, s := range stack[i:] {
+				chain = append(chain, r.relative(s))
+			}
+			return "", nil, fmt.Errorf("include cycle: %s -> %s", strings.Join(chain, " -> "), r.relative(resolved))
+		}
+	}
+	if len(stack) > maxIncludeDepth {
+		return "", nil, fmt.Errorf("includes are nested more than %d levels deep", maxIncludeDepth)
+	}
+
+	text, err := r.load(resolved)
+	if err != nil {
+		return "", nil, err
+	}
+	if heading != "" {
+		section, ok := content.ExtractSection(text, heading)
+		if !ok {
+			return "", nil, fmt.Errorf("heading %q not found", heading)
+		}
+		text = section
+	}
+
+	text = rebaseLinks(text, filepath.Dir(resolved), filepath.Dir(path))
+	expanded, errs := r.expand(text, resolved, append(stack[:len(stack):len(stack)], resolved))
+	return strings.TrimRight(expanded, "\n"), errs, nil
+}
+
+// load returns the content of an included file, without its frontmatter if
+// it is a markdown file that has one
+func (r *includeResolver) load(path string) (string, error) {
+	text, err := r.cp.LoadText(path)
+	if err != nil {
+		return "", fmt.Errorf("file not found")
+	}
+	normalized := strings.ReplaceAll(text, "\r\n", "\n")
+	if filepath.Ext(path) != markdownExt || !strings.HasPrefix(normalized, "---\n") {
+		return text, nil
+	}
+	md, err := r.cp.LoadMarkdownWithFrontmatter(path)
+	if err != nil {
+		return "", err
+	}
+	return md.Content, nil
+}
+
+// relative returns path relative to the resources directory, for messages
+func (r *includeResolver) relative(path string) string {
+	if rel, err := filepath.Rel(r.resourcesDir, path); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return path
+}
+
+// rebaseLinks rewrites the relative markdown link targets of content moved
+// from fromDir to toDir so that they still point to the same files
+func rebaseLinks(text, fromDir, toDir string) string {
+	if fromDir == toDir {
+		return text
+	}
+	return markdownLinkRe.ReplaceAllStringFunc(text, func(match string) string {
+		target := markdownLinkRe.FindStringSubmatch(match)[2]
+		if strings.HasPrefix(target, "#") || strings.HasPrefix(target, "/") || strings.Contains(target, ":") {
+			return match
+		}
+
+		filePart, fragment, hasFragment := strings.Cut(target, "#")
+		rebased, err := filepath.Rel(toDir, filepath.Join(fromDir, filepath.FromSlash(filePart)))
+		if err != nil {
+			return match
+		}
+		rebased = filepath.ToSlash(rebased)
+		if hasFragment {
+			rebased += "#" + fragment
+		}
+		return strings.Replace(match, "]("+target, "]("+rebased, 1)
+	})
+}
+
+// NewIncludeTransformer creates a ContentTransformer that replaces include
+// directives, e.g. {{< include "shared/escalation.md" >}}, with the content
+// of the included file. Includes are expanded recursively. Directives that
+// cannot be resolved are logged and left as-is; FindUnresolvedIncludes
+// reports them for validation.
+func NewIncludeTransformer(resourcesDir string) ContentTransformer {
+	resolver := newIncludeResolver(resourcesDir)
+
+	return func(content string, currentDef ResourceDefinition) string {
+		result, errs := resolver.expand(content, currentDef.FilePath, []string{currentDef.FilePath})
+		for _, err := range errs {
+			slog.Warn("Unresolved include", "uri", currentDef.URI, "error", err)
+		}
+		return result
+	}
+}
+
+// FindUnresolvedIncludes reports every include directive in the given
+// markdown resources that cannot be resolved: missing files and headings,
+// paths outside the resources directory, cycles and excessive nesting.
+func FindUnresolvedIncludes(definitions []ResourceDefinition, resourcesDir string) []domain.Issue {
+	resolver := newIncludeResolver(resourcesDir)
+	cp := content.NewContentProvider("")
+
+	var issues []domain.Issue
+	for _, defn := range definitions {
+		if !defn.isMarkdown() {
+			continue
+		}
+		md, err := cp.LoadMarkdownWithFrontmatter(defn.FilePath)
+		if err != nil {
+			issues = append(issues, domain.NewError(domain.RuleContentReadError, defn.FilePath, "%v", err))
+			continue
+		}
+
+		_, errs := resolver.expand(md.Content, defn.FilePath, []string{defn.FilePath})
+		for _, err := range errs {
+			issues = append(issues, domain.NewError(domain.RuleInclude, defn.FilePath, "%v", err))
+		}
+	}
+	return issues
+}
@@ -0,0 +1,168 @@
+package resources
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sha1n/mcp-acdc-server/internal/content"
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeResources writes files relative to the resources directory of a new
+// content directory and returns the content directory
+func writeResources(t *testing.T, files map[string]string) string {
+	t.Helper()
+	tmp := t.TempDir()
+	for name, body := range files {
+		path := filepath.Join(tmp, "mcp-resources", name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(body), 0644))
+	}
+	return tmp
+}
+
+func TestIncludeTransformer(t *testing.T) {
+	tmp := writeResources(t, map[string]string{
+		"_includes/escalation.md": "Page the [on-call](../teams/oncall.md#rota) lead.\n",
+		"shared/contacts.md":      "---\nname: Contacts\ndescription: Contacts\n---\n# Contacts\n\n## Email\n\nops@example.com\n\n## Phone\n\n555\n",
+		"shared/nested.md":        "Before {{< include \"contacts.md#Phone\" >}} after",
+		"cycle/a.md":              "A {{< include \"b.md\" >}}",
+		"cycle/b.md":              "B {{< include \"a.md\" >}}",
+	})
+	resourcesDir := filepath.Join(tmp, "mcp-resources")
+	transform := NewIncludeTransformer(resourcesDir)
+	defn := ResourceDefinition{URI: "acdc://ops/runbook", FilePath: filepath.Join(resourcesDir, "ops", "runbook.md")}
+
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			"RelativePathWithRebasedLinks",
+			`Escalation: {{< include "../_includes/escalation.md" >}}`,
+			"Escalation: Page the [on-call](../teams/oncall.md#rota) lead.",
+		},
+		{
+			"RootPath",
+			`{{<include "/_includes/escalation.md">}}`,
+			"Page the [on-call](../teams/oncall.md#rota) lead.",
+		},
+		{
+			"SectionWithoutFrontmatter",
+			`{{< include "../shared/contacts.md#email" >}}`,
+			"## Email\n\nops@example.com",
+		},
+		{
+			"Nested",
+			`{{< include "/shared/nested.md" >}}`,
+			"Before ## Phone\n\n555 after",
+		},
+		{
+			"MissingFileLeftAsIs",
+			`See {{< include "missing.md" >}}`,
+			`See {{< include "missing.md" >}}`,
+		},
+		{
+			"FencedCodeLeftAsIs",
+			"```markdown\n{{< include \"/_includes/escalation.md\" >}}\n```\n{{< include \"/_includes/escalation.md\" >}}",
+			"```markdown\n{{< include \"/_includes/escalation.md\" >}}\n```\nPage the [on-call](../teams/oncall.md#rota) lead.",
+		},
+		{
+			"CycleLeftAsIs",
+			`{{< include "/cycle/a.md" >}}`,
+			`A B {{< include "a.md" >}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, transform(tt.content, defn))
+		})
+	}
+}
+
+func TestResourceProvider_SizeOfExpandedContent(t *testing.T) {
+	tmp := writeResources(t, map[string]string{
+		"guide.md":          "---\nname: Guide\ndescription: G\n---\n{{< include \"_includes/long.md\" >}}",
+		"_includes/long.md": "A snippet that is longer than the directive that includes it.",
+	})
+	cp := content.NewContentProvider(tmp)
+	defs, _, err := DiscoverResourcesWithIssues(cp, "acdc")
+	require.NoError(t, err)
+	require.Len(t, defs, 1)
+
+	p := NewResourceProvider(defs, WithTransformer(NewIncludeTransformer(cp.ResourcesDir)))
+	text, err := p.ReadResource("acdc://guide")
+	require.NoError(t, err)
+	res, ok := p.GetResource("acdc://guide")
+	require.True(t, ok)
+	assert.Equal(t, int64(len(text)), res.Size)
+	assert.Equal(t, int64(len(text)), p.ListResources()[0].Size)
+}
+
+func TestFindUnresolvedIncludes(t *testing.T) {
+	tmp := writeResources(t, map[string]string{
+		"guide.md":        "---\nname: Guide\ndescription: G\n---\n{{< include \"_includes/ok.md\" >}}\n{{< include \"_includes/ok.md#Nope\" >}}\n{{< include \"missing.md\" >}}\n{{< include \"../../etc/passwd\" >}}",
+		"loop.md":         "---\nname: Loop\ndescription: L\n---\n{{< include \"loop.md\" >}}",
+		"_includes/ok.md": "Snippet",
+	})
+	cp := content.NewContentProvider(tmp)
+
+	defs, issues, err := DiscoverResourcesWithIssues(cp, "acdc")
+	require.NoError(t, err)
+	assert.Empty(t, issues, "snippets without frontmatter are not discovered")
+	require.Len(t, defs, 2)
+
+	issues = FindUnresolvedIncludes(defs, cp.ResourcesDir)
+	require.Len(t, issues, 4)
+	for _, issue := range issues {
+		assert.Equal(t, domain.RuleInclude, issue.Rule)
+		assert.Equal(t, domain.SeverityError, issue.Severity)
+	}
+	assert.Contains(t, issues[0].Message, `heading "Nope" not found`)
+	assert.Contains(t, issues[1].Message, `include "missing.md" in guide.md: file not found`)
+	assert.Contains(t, issues[2].Message, "outside of the resources directory")
+	assert.Contains(t, issues[3].Message, "include cycle: loop.md -> loop.md")
+}
+
+func TestDiscoverResourceTemplates_Includes(t *testing.T) {
+	tmp := writeResources(t, map[string]string{
+		"services/_service.md": "---\nname: Service\ndescription: D\nuri_template: acdc://services/{name}\n---\n# {{.name}}\n{{< include \"../_includes/owner.md\" >}}",
+		"services/_broken.md":  "---\nname: Broken\ndescription: D\nuri_template: acdc://broken/{name}\n---\n{{< include \"missing.md\" >}}",
+		"_includes/owner.md":   "Owned by the {{.name}} team.",
+	})
+
+	defs, issues, err := DiscoverResourceTemplatesWithIssues(content.NewContentProvider(tmp))
+	require.NoError(t, err)
+	require.Len(t, defs, 1)
+	require.Len(t, issues, 1)
+	assert.Equal(t, domain.RuleInclude, issues[0].Rule)
+	assert.Contains(t, issues[0].Message, `include "missing.md"`)
+
+	p := NewResourceProvider(nil, WithTemplates(defs))
+	text, err := p.ReadResource("acdc://services/checkout")
+	require.NoError(t, err)
+	assert.Equal(t, "# checkout\nOwned by the checkout team.", text)
+}
+
+func TestFindUnresolvedIncludes_FencedCode(t *testing.T) {
+	tmp := writeResources(t, map[string]string{
+		"guide.md": "---\nname: Guide\ndescription: G\n---\nUsage:\n\n~~~\n{{< include \"path/to/snippet.md\" >}}\n~~~\n",
+	})
+	cp := content.NewContentProvider(tmp)
+
+	defs, _, err := DiscoverResourcesWithIssues(cp, "acdc")
+	require.NoError(t, err)
+	assert.Empty(t, FindUnresolvedIncludes(defs, cp.ResourcesDir))
+}
+
+func TestRebaseLinks(t *testing.T) {
+	text := `[a](b.md) [c](../c.md#x "T") ![img](img/p.png) [ext](https://x.io) [frag](#y)`
+	assert.Equal(t,
+		`[a](../shared/b.md) [c](../c.md#x "T") ![img](../shared/img/p.png) [ext](https://x.io) [frag](#y)`,
+		rebaseLinks(text, "/r/shared", "/r/ops"))
+}
@@ -2,11 +2,15 @@ package resources
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io/fs"
 	"log/slog"
+	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/sha1n/mcp-acdc-server/internal/content"
@@ -32,18 +36,31 @@ func WithTransformer(t ContentTransformer) Option {
 type ResourceProvider struct {
 	definitions  []ResourceDefinition
 	uriMap       map[string]ResourceDefinition
+	aliases      map[string]string // Alias to canonical URI
 	transformers []ContentTransformer
+	templates    []compiledTemplate
+	extractors   map[string]Extractor
+
+	sizesMu sync.Mutex
+	sizes   map[string]int64 // Size of transformed markdown content by URI, recorded on read
 }
 
 // NewResourceProvider creates a new resource provider
 func NewResourceProvider(definitions []ResourceDefinition, opts ...Option) *ResourceProvider {
 	uriMap := make(map[string]ResourceDefinition)
+	aliases := make(map[string]string)
 	for _, d := range definitions {
 		uriMap[d.URI] = d
+		for _, alias := range d.Aliases {
+			aliases[alias] = d.URI
+		}
 	}
 	p := &ResourceProvider{
 		definitions: definitions,
 		uriMap:      uriMap,
+		aliases:     aliases,
+		extractors:  defaultExtractors(),
+		sizes:       make(map[string]int64),
 	}
 	for _, opt := range opts {
 		opt(p)
@@ -51,28 +68,79 @@ func NewResourceProvider(definitions []ResourceDefinition, opts ...Option) *Reso
 	return p
 }
 
+// resource returns the listing of a discovered resource. Markdown resources
+// that have been read, e.g. for indexing, are listed with the size of their
+// transformed content, which is what is served, e.g. with includes expanded.
+func (p *ResourceProvider) resource(d ResourceDefinition) mcp.Resource {
+	p.sizesMu.Lock()
+	size, ok := p.sizes[d.URI]
+	p.sizesMu.Unlock()
+	if ok {
+		d.Size = size
+	}
+	return d.resource()
+}
+
 // ListResources lists all available resources
 func (p *ResourceProvider) ListResources() []mcp.Resource {
 	resources := make([]mcp.Resource, len(p.definitions))
 	for i, d := range p.definitions {
-		resources[i] = mcp.Resource{
-			URI:         d.URI,
-			Name:        d.Name,
-			Description: d.Description,
-			MIMEType:    d.MIMEType,
-		}
+		resources[i] = p.resource(d)
 	}
 	return resources
 }
 
-// ReadResource reads a resource by URI
+// GetResource returns the listing of the discovered resource with the given
+// URI or alias. The listing of an alias has the canonical URI. Templated URIs
+// are not discovered resources.
+func (p *ResourceProvider) GetResource(uri string) (mcp.Resource, bool) {
+	d, ok := p.lookup(uri)
+	if !ok {
+		return mcp.Resource{}, false
+	}
+	return p.resource(d), true
+}
+
+// ResolveAlias returns the canonical URI of an alias. ok is false if uri is
+// not an alias.
+func (p *ResourceProvider) ResolveAlias(uri string) (canonical string, ok bool) {
+	canonical, ok = p.aliases[uri]
+	return canonical, ok
+}
+
+// lookup returns the definition of the resource with the given URI or alias
+func (p *ResourceProvider) lookup(uri string) (ResourceDefinition, bool) {
+	if canonical, ok := p.aliases[uri]; ok {
+		uri = canonical
+	}
+	d, ok := p.uriMap[uri]
+	return d, ok
+}
+
+// ReadResource reads a resource by URI or alias. URIs that do not match a
+// discovered resource are rendered from a matching resource template, if any. Content
+// transformers only apply to markdown resources; other formats are returned
+// as-is, except for attachments, which are read as their extracted text.
 func (p *ResourceProvider) ReadResource(uri string) (string, error) {
-	defn, ok := p.uriMap[uri]
+	defn, ok := p.lookup(uri)
 	if !ok {
-		return "", fmt.Errorf("unknown resource: %s", uri)
+		return p.readTemplatedResource(uri)
+	}
+
+	if defn.isAttachment() {
+		data, err := os.ReadFile(defn.FilePath)
+		if err != nil {
+			return "", err
+		}
+		return extractText(p.extractors, defn.MIMEType, data)
+	}
+
+	cp := content.NewContentProvider("")
+	if !defn.isMarkdown() {
+		return cp.LoadText(defn.FilePath)
 	}
 
-	c, err := content.NewContentProvider("").LoadMarkdownWithFrontmatter(defn.FilePath)
+	c, err := cp.LoadMarkdownWithFrontmatter(defn.FilePath)
 	if err != nil {
 		return "", err
 	}
@@ -81,9 +149,38 @@ func (p *ResourceProvider) ReadResource(uri string) (string, error) {
 	for _, t := range p.transformers {
 		result = t(result, defn)
 	}
+	if len(p.transformers) > 0 {
+		p.sizesMu.Lock()
+		p.sizes[defn.URI] = int64(len(result))
+		p.sizesMu.Unlock()
+	}
 	return result, nil
 }
 
+// ReadBlob reads the raw content of an attachment. ok is false if the URI does
+// not name an attachment.
+func (p *ResourceProvider) ReadBlob(uri string) (data []byte, ok bool, err error) {
+	defn, found := p.lookup(uri)
+	if !found || !defn.isAttachment() {
+		return nil, false, nil
+	}
+	data, err = os.ReadFile(defn.FilePath)
+	return data, true, err
+}
+
+// IsAttachment reports whether the URI names an attachment, which is served
+// as a blob and read by tools as its extracted text
+func (p *ResourceProvider) IsAttachment(uri string) bool {
+	d, _ := p.lookup(uri)
+	return d.isAttachment()
+}
+
+// Attachments returns the URIs of the attachments of the resource
+func (p *ResourceProvider) Attachments(uri string) []string {
+	d, _ := p.lookup(uri)
+	return d.Attachments
+}
+
 // StreamResources streams all resource contents to a channel
 func (p *ResourceProvider) StreamResources(ctx context.Context, ch chan<- domain.Document) error {
 	for _, defn := range p.definitions {
@@ -94,16 +191,23 @@ func (p *ResourceProvider) StreamResources(ctx context.Context, ch chan<- domain
 		}
 
 		content, err := p.ReadResource(defn.URI)
-		if err != nil {
+		switch {
+		case errors.Is(err, errNoExtractor):
+			// Attachments without text, such as images, are found by name
+			slog.Debug("Indexing attachment without text", "uri", defn.URI, "mime_type", defn.MIMEType)
+		case err != nil:
 			slog.Error("Error reading resource for indexing", "uri", defn.URI, "error", err)
 			continue
 		}
 
 		doc := domain.Document{
-			URI:      defn.URI,
-			Name:     defn.Name,
-			Content:  content,
-			Keywords: defn.Keywords,
+			URI:        defn.URI,
+			Name:       defn.Name,
+			Content:    indexText(defn.MIMEType, content),
+			Keywords:   defn.Keywords,
+			Priority:   defn.Priority,
+			Updated:    defn.Updated,
+			Deprecated: defn.Deprecated,
 		}
 
 		select {
@@ -117,34 +221,95 @@ func (p *ResourceProvider) StreamResources(ctx context.Context, ch chan<- domain
 
 // DiscoverResources discovers resources from markdown files.
 // The scheme parameter specifies the URI scheme (e.g. "acdc" produces "acdc://...").
+// Invalid files are logged and skipped.
 func DiscoverResources(cp *content.ContentProvider, scheme string) ([]ResourceDefinition, error) {
+	definitions, _, err := DiscoverResourcesWithIssues(cp, scheme)
+	return definitions, err
+}
+
+// DiscoverResourcesWithIssues discovers resources like DiscoverResources and
+// also returns an issue for every file that was skipped.
+func DiscoverResourcesWithIssues(cp *content.ContentProvider, scheme string) ([]ResourceDefinition, []domain.Issue, error) {
 	var definitions []ResourceDefinition
+	var issues []domain.Issue
+	uriToPath := make(map[string]string)
 	resourcesDir := cp.ResourcesDir
+	lastModified := content.NewLastModifiedResolver(resourcesDir)
+	sidecars := newSidecarMetadata(cp)
+	redirectsPath := filepath.Join(resourcesDir, redirectsFileName)
+	attachments := &attachmentDiscovery{
+		resourcesDir: resourcesDir,
+		scheme:       scheme,
+		uriToPath:    uriToPath,
+		lastModified: lastModified,
+	}
 
 	err := filepath.WalkDir(resourcesDir, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
-		if d.IsDir() {
+		if path == redirectsPath {
 			return nil
 		}
-		if filepath.Ext(path) != ".md" {
+		if d.IsDir() {
+			// Snippets are only included by other resources
+			if d.Name() == includesDirName {
+				return filepath.SkipDir
+			}
 			return nil
 		}
 
-		// Parse frontmatter
-		md, err := cp.LoadMarkdownWithFrontmatter(path)
-		if err != nil {
-			slog.Warn("Skipping invalid resource file", "file", d.Name(), "error", err)
+		var md *content.MarkdownWithFrontmatter
+		mimeType := MIMETypeMarkdown
+		switch {
+		case filepath.Ext(path) == markdownExt:
+			// Parse frontmatter
+			md, err = cp.LoadMarkdownWithFrontmatter(path)
+			if err != nil {
+				slog.Warn("Skipping invalid resource file", "file", d.Name(), "error", err)
+				issues = append(issues, domain.NewError(domain.RuleFrontmatter, path, "%v", err))
+				return nil
+			}
+
+			// Resource template files are discovered separately
+			if _, ok := md.Metadata[uriTemplateField]; ok {
+				return nil
+			}
+
+		case d.Name() == manifestFileName:
+			sidecars.manifest(filepath.Dir(path))
+			return nil
+
+		case isMetadataFile(d.Name()):
+			sidecars.checkSidecar(path)
 			return nil
+
+		default:
+			var supported bool
+			if mimeType, supported = mimeTypeOf(path); !supported {
+				return nil
+			}
+			metadata, ok := sidecars.lookup(path)
+			if !ok {
+				slog.Debug("Ignoring file without sidecar or manifest metadata", "file", d.Name())
+				return nil
+			}
+			text, err := cp.LoadText(path)
+			if err != nil {
+				slog.Warn("Skipping unreadable resource file", "file", d.Name(), "error", err)
+				issues = append(issues, domain.NewError(domain.RuleContentReadError, path, "%v", err))
+				return nil
+			}
+			md = &content.MarkdownWithFrontmatter{Metadata: metadata, Content: text}
 		}
 
 		// Extract metadata
 		name, _ := md.Metadata["name"].(string)
 		description, _ := md.Metadata["description"].(string)
 
-		if name == "" || description == "" {
+		if missing := md.MissingFields("name", "description"); len(missing) > 0 {
 			slog.Warn("Skipping resource with missing metadata", "file", d.Name())
+			issues = append(issues, domain.NewError(domain.RuleRequiredField, path, "missing required %s field(s): %s", metadataSource(mimeType), strings.Join(missing, ", ")))
 			return nil
 		}
 
@@ -158,34 +323,142 @@ func DiscoverResources(cp *content.ContentProvider, scheme string) ([]ResourceDe
 			}
 		}
 
-		// Derive URI
+		// Extract optional ranking metadata
+		priority, updated, deprecated := parseRankingMetadata(md.Metadata, d.Name())
+		audience, importance := parseAnnotations(md.Metadata, d.Name())
+
+		var modified *time.Time
+		if t, err := lastModified.LastModified(path); err == nil {
+			modified = &t
+		}
+
+		// Derive URI, unless set explicitly
 		relPath, err := filepath.Rel(resourcesDir, path)
 		if err != nil {
 			return err
 		}
 
-		relPathNoExt := strings.TrimSuffix(relPath, filepath.Ext(relPath))
-		// normalized for URI (slashes)
-		uriPath := filepath.ToSlash(relPathNoExt)
-		uri := fmt.Sprintf("%s://%s", scheme, uriPath)
+		uri, err := metadataURI(md.Metadata, scheme)
+		if err != nil {
+			slog.Warn("Skipping resource with invalid URI override", "file", d.Name(), "error", err)
+			issues = append(issues, domain.NewError(domain.RuleFrontmatter, path, "%v", err))
+			return nil
+		}
+		if uri == "" {
+			// Markdown URIs omit the extension, other formats keep it
+			uriPath := relPath
+			if mimeType == MIMETypeMarkdown {
+				uriPath = strings.TrimSuffix(relPath, filepath.Ext(relPath))
+			}
+			// normalized for URI (slashes)
+			uriPath = filepath.ToSlash(uriPath)
+			uri = fmt.Sprintf("%s://%s", scheme, uriPath)
+		}
 
-		definitions = append(definitions, ResourceDefinition{
-			URI:         uri,
-			Name:        name,
-			Description: description,
-			MIMEType:    "text/markdown",
-			FilePath:    path,
-			Keywords:    keywords,
-		})
+		if existing, ok := uriToPath[uri]; ok {
+			slog.Warn("Skipping resource with duplicate URI", "file", d.Name(), "uri", uri)
+			issues = append(issues, domain.NewError(domain.RuleDuplicateURI, path, "URI %s is already used by %s", uri, existing))
+			return nil
+		}
+		uriToPath[uri] = filepath.ToSlash(relPath)
 
+		defn := ResourceDefinition{
+			URI:          uri,
+			Name:         name,
+			Description:  description,
+			MIMEType:     mimeType,
+			FilePath:     path,
+			Keywords:     keywords,
+			Priority:     priority,
+			Updated:      updated,
+			Deprecated:   deprecated,
+			Aliases:      parseAliases(md.Metadata, scheme, d.Name()),
+			Audience:     audience,
+			Importance:   importance,
+			Size:         int64(len(md.Content)),
+			LastModified: modified,
+		}
 		slog.Info("Loaded resource", "uri", uri, "name", name)
 
+		// Attachments are listed right after their resource
+		var attached []ResourceDefinition
+		if mimeType == MIMETypeMarkdown {
+			var attachmentIssues []domain.Issue
+			attached, attachmentIssues = attachments.discover(&defn, md.Metadata)
+			issues = append(issues, attachmentIssues...)
+		}
+		definitions = append(definitions, defn)
+		definitions = append(definitions, attached...)
+
 		return nil
 	})
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	issues = append(issues, sidecars.issues...)
+	issues = append(issues, sidecars.unusedManifestEntries()...)
+
+	redirects, err := loadRedirects(cp, scheme)
+	if err != nil {
+		slog.Warn("Ignoring invalid redirects file", "error", err)
+		issues = append(issues, domain.NewError(domain.RuleAlias, redirectsPath, "%v", err))
 	}
+	issues = append(issues, applyAliases(definitions, redirects, redirectsPath)...)
+	return definitions, issues, nil
+}
 
-	return definitions, nil
+// metadataSource names where the metadata of a resource of the given MIME type
+// is declared, for error messages
+func metadataSource(mimeType string) string {
+	if mimeType == MIMETypeMarkdown {
+		return "frontmatter"
+	}
+	return "sidecar or manifest"
+}
+
+// parseRankingMetadata extracts the optional priority, updated and deprecated
+// frontmatter fields. Invalid values are logged and ignored.
+func parseRankingMetadata(metadata map[string]interface{}, fileName string) (float64, *time.Time, bool) {
+	var priority float64
+	switch v := metadata["priority"].(type) {
+	case nil:
+	case int:
+		priority = float64(v)
+	case float64:
+		priority = v
+	default:
+		slog.Warn("Ignoring non-numeric priority", "file", fileName, "value", v)
+	}
+
+	var updated *time.Time
+	switch v := metadata["updated"].(type) {
+	case nil:
+	case time.Time:
+		updated = &v
+	case string:
+		if t, err := parseDate(v); err == nil {
+			updated = &t
+		} else {
+			slog.Warn("Ignoring invalid updated date", "file", fileName, "value", v, "error", err)
+		}
+	default:
+		slog.Warn("Ignoring invalid updated date", "file", fileName, "value", v)
+	}
+
+	deprecated, ok := metadata["deprecated"].(bool)
+	if !ok && metadata["deprecated"] != nil {
+		slog.Warn("Ignoring non-boolean deprecated flag", "file", fileName, "value", metadata["deprecated"])
+	}
+
+	return priority, updated, deprecated
+}
+
+// parseDate parses a date in either YYYY-MM-DD or RFC 3339 format
+func parseDate(s string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, s)
 }
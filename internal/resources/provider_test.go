@@ -403,3 +403,117 @@ func TestDiscoverResources_CustomScheme(t *testing.T) {
 		t.Errorf("Expected URI 'my-custom://doc', got '%s'", defs[0].URI)
 	}
 }
+
+func TestDiscoverResources_RankingMetadata(t *testing.T) {
+	tmp := t.TempDir()
+	resDir := filepath.Join(tmp, "mcp-resources")
+	if err := os.MkdirAll(resDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"ranked.md":  "---\nname: Ranked\ndescription: D\npriority: 2\nupdated: 2024-05-01\ndeprecated: true\n---\nContent",
+		"rfc3339.md": "---\nname: RFC\ndescription: D\npriority: 0.5\nupdated: \"2024-05-01T10:00:00Z\"\n---\nContent",
+		"invalid.md": "---\nname: Invalid\ndescription: D\npriority: high\nupdated: yesterday\ndeprecated: maybe\n---\nContent",
+		"plain.md":   "---\nname: Plain\ndescription: D\n---\nContent",
+	}
+	for name, body := range files {
+		if err := os.WriteFile(filepath.Join(resDir, name), []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	defs, err := DiscoverResources(content.NewContentProvider(tmp), "acdc")
+	if err != nil {
+		t.Fatalf("DiscoverResources error = %v", err)
+	}
+
+	byURI := make(map[string]ResourceDefinition)
+	for _, d := range defs {
+		byURI[d.URI] = d
+	}
+	if len(byURI) != 4 {
+		t.Fatalf("DiscoverResources found %d items, want 4", len(byURI))
+	}
+
+	ranked := byURI["acdc://ranked"]
+	if ranked.Priority != 2 || !ranked.Deprecated {
+		t.Errorf("Expected priority 2 and deprecated, got %+v", ranked)
+	}
+	if ranked.Updated == nil || !ranked.Updated.Equal(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected updated 2024-05-01, got %v", ranked.Updated)
+	}
+
+	rfc := byURI["acdc://rfc3339"]
+	if rfc.Priority != 0.5 || rfc.Updated == nil || rfc.Updated.Hour() != 10 {
+		t.Errorf("Expected priority 0.5 and RFC 3339 updated time, got %+v", rfc)
+	}
+
+	invalid := byURI["acdc://invalid"]
+	if invalid.Priority != 0 || invalid.Updated != nil || invalid.Deprecated {
+		t.Errorf("Expected invalid ranking metadata to be ignored, got %+v", invalid)
+	}
+
+	plain := byURI["acdc://plain"]
+	if plain.Priority != 0 || plain.Updated != nil || plain.Deprecated {
+		t.Errorf("Expected no ranking metadata, got %+v", plain)
+	}
+}
+
+func TestDiscoverResourcesWithIssues(t *testing.T) {
+	tmp := t.TempDir()
+	resDir := filepath.Join(tmp, "mcp-resources")
+	if err := os.MkdirAll(resDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"valid.md":       "---\nname: Valid\ndescription: D\n---\nContent",
+		"no-fm.md":       "Content without frontmatter",
+		"no-desc.md":     "---\nname: No Description\n---\nContent",
+		"bad-yaml.md":    "---\nname: [unclosed\n---\nContent",
+		"no-fields.md":   "---\nkeywords: [a]\n---\nContent",
+		"not-markdown.x": "ignored",
+	}
+	for name, body := range files {
+		if err := os.WriteFile(filepath.Join(resDir, name), []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	defs, issues, err := DiscoverResourcesWithIssues(content.NewContentProvider(tmp), "acdc")
+	if err != nil {
+		t.Fatalf("DiscoverResourcesWithIssues error = %v", err)
+	}
+	if len(defs) != 1 || defs[0].URI != "acdc://valid" {
+		t.Errorf("Expected only acdc://valid, got %+v", defs)
+	}
+
+	rules := make(map[string]string)
+	for _, issue := range issues {
+		if issue.Severity != domain.SeverityError {
+			t.Errorf("Expected error severity, got %s", issue.Severity)
+		}
+		rules[filepath.Base(issue.File)] = issue.Rule
+	}
+	want := map[string]string{
+		"no-fm.md":     domain.RuleFrontmatter,
+		"bad-yaml.md":  domain.RuleFrontmatter,
+		"no-desc.md":   domain.RuleRequiredField,
+		"no-fields.md": domain.RuleRequiredField,
+	}
+	if len(rules) != len(want) {
+		t.Errorf("Expected %d issues, got %+v", len(want), issues)
+	}
+	for file, rule := range want {
+		if rules[file] != rule {
+			t.Errorf("Expected %s issue for %s, got %q", rule, file, rules[file])
+		}
+	}
+
+	for _, issue := range issues {
+		if filepath.Base(issue.File) == "no-fields.md" && !strings.Contains(issue.Message, "name, description") {
+			t.Errorf("Expected both missing fields in message, got %q", issue.Message)
+		}
+	}
+}
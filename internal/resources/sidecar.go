@@ -0,0 +1,157 @@
+package resources
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sha1n/mcp-acdc-server/internal/content"
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+)
+
+const (
+	// sidecarSuffix is appended to the file name of a non-markdown resource
+	// to name the file holding its metadata, e.g. openapi.json.meta.yaml
+	sidecarSuffix = ".meta.yaml"
+	// manifestFileName is the file holding the metadata of the non-markdown
+	// resources of a directory, keyed by file name
+	manifestFileName = "_meta.yaml"
+)
+
+// isMetadataFile reports whether the file is a sidecar or manifest rather
+// than a resource
+func isMetadataFile(name string) bool {
+	return name == manifestFileName || strings.HasSuffix(name, sidecarSuffix)
+}
+
+// manifest is the parsed metadata manifest of a directory
+type manifest struct {
+	entries map[string]map[string]interface{} // File name to metadata
+	used    map[string]bool
+}
+
+// sidecarMetadata looks up the metadata of non-markdown resources in sidecar
+// files and directory manifests. Manifests are loaded once per directory.
+type sidecarMetadata struct {
+	cp        *content.ContentProvider
+	manifests map[string]*manifest // Directory to manifest, nil if absent or invalid
+	issues    []domain.Issue
+}
+
+func newSidecarMetadata(cp *content.ContentProvider) *sidecarMetadata {
+	return &sidecarMetadata{cp: cp, manifests: make(map[string]*manifest)}
+}
+
+// lookup returns the metadata of the resource file at path. A sidecar file
+// takes precedence over a manifest entry. ok is false if the file has no
+// metadata or its sidecar is invalid.
+func (s *sidecarMetadata) lookup(path string) (map[string]interface{}, bool) {
+	name := filepath.Base(path)
+	m := s.manifest(filepath.Dir(path))
+	if m != nil {
+		m.used[name] = true
+	}
+
+	sidecarPath := path + sidecarSuffix
+	if _, err := os.Stat(sidecarPath); err == nil {
+		metadata, err := s.cp.LoadYAML(sidecarPath)
+		if err != nil {
+			slog.Warn("Skipping resource with invalid sidecar metadata", "file", name, "error", err)
+			s.issues = append(s.issues, domain.NewError(domain.RuleFrontmatter, sidecarPath, "%v", err))
+			return nil, false
+		}
+		if metadata == nil {
+			metadata = map[string]interface{}{}
+		}
+		return metadata, true
+	}
+
+	if m == nil {
+		return nil, false
+	}
+	metadata, ok := m.entries[name]
+	return metadata, ok
+}
+
+// manifest returns the manifest of dir, loading it on first use
+func (s *sidecarMetadata) manifest(dir string) *manifest {
+	if m, ok := s.manifests[dir]; ok {
+		return m
+	}
+	m, err := s.loadManifest(filepath.Join(dir, manifestFileName))
+	if err != nil {
+		slog.Warn("Ignoring invalid resource manifest", "path", dir, "error", err)
+		s.issues = append(s.issues, domain.NewError(domain.RuleFrontmatter, filepath.Join(dir, manifestFileName), "%v", err))
+	}
+	s.manifests[dir] = m
+	return m
+}
+
+func (s *sidecarMetadata) loadManifest(path string) (*manifest, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, nil
+	}
+	raw, err := s.cp.LoadYAML(path)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &manifest{entries: make(map[string]map[string]interface{}, len(raw)), used: make(map[string]bool)}
+	for name, value := range raw {
+		metadata, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("entry %q in %s must be a map of metadata fields", name, path)
+		}
+		m.entries[name] = metadata
+	}
+	return m, nil
+}
+
+// checkSidecar reports a sidecar file that does not describe a supported
+// non-markdown resource
+func (s *sidecarMetadata) checkSidecar(path string) {
+	target := strings.TrimSuffix(path, sidecarSuffix)
+	name := filepath.Base(target)
+	switch _, supported := mimeTypeOf(target); {
+	case filepath.Ext(target) == markdownExt:
+		s.issues = append(s.issues, domain.NewWarning(domain.RuleFrontmatter, path, "sidecar metadata is ignored for markdown file %s, use frontmatter instead", name))
+	case !supported:
+		s.issues = append(s.issues, domain.NewWarning(domain.RuleFrontmatter, path, "sidecar metadata is ignored, %s is not a supported resource type", name))
+	default:
+		if _, err := os.Stat(target); err != nil {
+			s.issues = append(s.issues, domain.NewWarning(domain.RuleFrontmatter, path, "sidecar metadata is ignored, %s does not exist", name))
+		}
+	}
+}
+
+// unusedManifestEntries reports manifest entries that do not describe a
+// supported non-markdown resource
+func (s *sidecarMetadata) unusedManifestEntries() []domain.Issue {
+	dirs := make([]string, 0, len(s.manifests))
+	for dir := range s.manifests {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	var issues []domain.Issue
+	for _, dir := range dirs {
+		m := s.manifests[dir]
+		if m == nil {
+			continue
+		}
+		names := make([]string, 0, len(m.entries))
+		for name := range m.entries {
+			if !m.used[name] {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			issues = append(issues, domain.NewWarning(domain.RuleFrontmatter, filepath.Join(dir, manifestFileName), "metadata for %s is ignored, it does not name a supported resource file in this directory", name))
+		}
+	}
+	return issues
+}
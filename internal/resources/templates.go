@@ -0,0 +1,288 @@
+package resources
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sha1n/mcp-acdc-server/internal/content"
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+	"github.com/yosida95/uritemplate/v3"
+)
+
+// uriTemplateField is the frontmatter field that turns a resource file into a
+// resource template
+const uriTemplateField = "uri_template"
+
+// ResourceTemplateDefinition definition of an MCP resource template
+type ResourceTemplateDefinition struct {
+	URITemplate string
+	Name        string
+	Description string
+	MIMEType    string
+	FilePath    string             // Template file, empty for templates declared in metadata
+	Template    *template.Template // Rendered with the URI variables, nil to only match discovered resources
+}
+
+// compiledTemplate pairs a template definition with its URI matcher
+type compiledTemplate struct {
+	definition ResourceTemplateDefinition
+	matcher    *uritemplate.Template
+}
+
+// WithTemplates adds resource templates to the provider.
+// Templates with an invalid URI template are logged and skipped.
+func WithTemplates(definitions []ResourceTemplateDefinition) Option {
+	return func(p *ResourceProvider) {
+		for _, d := range definitions {
+			matcher, err := uritemplate.New(d.URITemplate)
+			if err != nil {
+				slog.Warn("Skipping resource template with invalid URI template", "uri_template", d.URITemplate, "error", err)
+				continue
+			}
+			p.templates = append(p.templates, compiledTemplate{definition: d, matcher: matcher})
+		}
+	}
+}
+
+// ListResourceTemplates lists all available resource templates
+func (p *ResourceProvider) ListResourceTemplates() []mcp.ResourceTemplate {
+	templates := make([]mcp.ResourceTemplate, len(p.templates))
+	for i, t := range p.templates {
+		templates[i] = mcp.ResourceTemplate{
+			URITemplate: t.definition.URITemplate,
+			Name:        t.definition.Name,
+			Description: t.definition.Description,
+			MIMEType:    t.definition.MIMEType,
+		}
+	}
+	return templates
+}
+
+// CompleteTemplateArgument returns the values of a URI template variable taken
+// from the discovered resources that match the template, filtered by the
+// partially typed value. Variables already resolved in context narrow the
+// matches down further.
+func (p *ResourceProvider) CompleteTemplateArgument(uriTemplate, argument, value string, context map[string]string) ([]string, error) {
+	var matcher *uritemplate.Template
+	for _, t := range p.templates {
+		if t.definition.URITemplate == uriTemplate {
+			matcher = t.matcher
+			break
+		}
+	}
+	if matcher == nil {
+		return nil, fmt.Errorf("unknown resource template: %s", uriTemplate)
+	}
+
+	values := []string{}
+	seen := make(map[string]bool)
+	lowerValue := strings.ToLower(value)
+	for _, d := range p.definitions {
+		match := matcher.Match(d.URI)
+		if match == nil || !matchesContext(match, context, argument) {
+			continue
+		}
+		candidate := match.Get(argument).String()
+		if candidate == "" || seen[candidate] || !strings.HasPrefix(strings.ToLower(candidate), lowerValue) {
+			continue
+		}
+		seen[candidate] = true
+		values = append(values, candidate)
+	}
+	return values, nil
+}
+
+// matchesContext reports whether the matched variables agree with the
+// already resolved ones, ignoring the variable being completed
+func matchesContext(match uritemplate.Values, context map[string]string, argument string) bool {
+	for name, resolved := range context {
+		if name == argument {
+			continue
+		}
+		if v := match.Get(name); v.Valid() && v.String() != resolved {
+			return false
+		}
+	}
+	return true
+}
+
+// MIMEType returns the MIME type of the content read from uri: that of the
+// discovered resource with this URI or alias, or else that of the first
+// template file matching it. ok is false if uri names neither.
+func (p *ResourceProvider) MIMEType(uri string) (mimeType string, ok bool) {
+	if defn, found := p.lookup(uri); found {
+		return defn.MIMEType, true
+	}
+	for _, t := range p.templates {
+		if t.definition.Template != nil && t.matcher.Match(uri) != nil {
+			return t.definition.MIMEType, true
+		}
+	}
+	return "", false
+}
+
+// readTemplatedResource renders the first template file whose URI template
+// matches uri. Templates without a template file only describe resources that
+// already exist, so they never match here.
+func (p *ResourceProvider) readTemplatedResource(uri string) (string, error) {
+	for _, t := range p.templates {
+		if t.definition.Template == nil {
+			continue
+		}
+		values := t.matcher.Match(uri)
+		if values == nil {
+			continue
+		}
+
+		vars := make(map[string]string, len(values))
+		for name, value := range values {
+			vars[name] = value.String()
+		}
+
+		var buf bytes.Buffer
+		if err := t.definition.Template.Execute(&buf, vars); err != nil {
+			return "", fmt.Errorf("failed to execute resource template: %w", err)
+		}
+
+		result := buf.String()
+		defn := ResourceDefinition{
+			URI:         uri,
+			Name:        t.definition.Name,
+			Description: t.definition.Description,
+			MIMEType:    t.definition.MIMEType,
+			FilePath:    t.definition.FilePath,
+		}
+		for _, transform := range p.transformers {
+			result = transform(result, defn)
+		}
+		return result, nil
+	}
+	return "", fmt.Errorf("unknown resource: %s", uri)
+}
+
+// TemplatesFromMetadata converts the resource templates declared in
+// mcp-metadata.yaml into definitions. These templates are resolved against
+// discovered resources only.
+func TemplatesFromMetadata(templates []domain.ResourceTemplateMetadata) []ResourceTemplateDefinition {
+	definitions := make([]ResourceTemplateDefinition, len(templates))
+	for i, t := range templates {
+		definitions[i] = ResourceTemplateDefinition{
+			URITemplate: t.URITemplate,
+			Name:        t.Name,
+			Description: t.Description,
+			MIMEType:    MIMETypeMarkdown,
+		}
+	}
+	return definitions
+}
+
+// DiscoverResourceTemplates discovers resource template files, which are
+// markdown files with a uri_template frontmatter field.
+// Invalid files are logged and skipped.
+func DiscoverResourceTemplates(cp *content.ContentProvider) ([]ResourceTemplateDefinition, error) {
+	definitions, _, err := DiscoverResourceTemplatesWithIssues(cp)
+	return definitions, err
+}
+
+// DiscoverResourceTemplatesWithIssues discovers resource templates like
+// DiscoverResourceTemplates and also returns an issue for every template file
+// that was skipped. Files with invalid frontmatter are left to resource
+// discovery to report.
+func DiscoverResourceTemplatesWithIssues(cp *content.ContentProvider) ([]ResourceTemplateDefinition, []domain.Issue, error) {
+	var definitions []ResourceTemplateDefinition
+	var issues []domain.Issue
+	templateToPath := make(map[string]string)
+	resourcesDir := cp.ResourcesDir
+	includes := newIncludeResolver(resourcesDir)
+
+	err := filepath.WalkDir(resourcesDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == includesDirName {
+			return filepath.SkipDir
+		}
+		if d.IsDir() || filepath.Ext(path) != ".md" {
+			return nil
+		}
+
+		md, err := cp.LoadMarkdownWithFrontmatter(path)
+		if err != nil {
+			return nil
+		}
+		if _, ok := md.Metadata[uriTemplateField]; !ok {
+			return nil
+		}
+
+		uriTemplate, _ := md.Metadata[uriTemplateField].(string)
+		name, _ := md.Metadata["name"].(string)
+		description, _ := md.Metadata["description"].(string)
+
+		if missing := md.MissingFields(uriTemplateField, "name", "description"); len(missing) > 0 {
+			slog.Warn("Skipping resource template with missing metadata", "file", d.Name())
+			issues = append(issues, domain.NewError(domain.RuleRequiredField, path, "missing required frontmatter field(s): %s", strings.Join(missing, ", ")))
+			return nil
+		}
+
+		if _, err := uritemplate.New(uriTemplate); err != nil {
+			slog.Warn("Skipping resource template with invalid URI template", "file", d.Name(), "error", err)
+			issues = append(issues, domain.NewError(domain.RuleTemplate, path, "invalid URI template %q: %v", uriTemplate, err))
+			return nil
+		}
+
+		if existing, ok := templateToPath[uriTemplate]; ok {
+			slog.Warn("Skipping resource template with duplicate URI template", "file", d.Name(), "uri_template", uriTemplate)
+			issues = append(issues, domain.NewError(domain.RuleDuplicateURI, path, "URI template %s is already used by %s", uriTemplate, existing))
+			return nil
+		}
+
+		// Includes are expanded before parsing, as their directives are not
+		// valid template actions
+		body, includeErrs := includes.expand(md.Content, path, []string{path})
+		if len(includeErrs) > 0 {
+			slog.Warn("Skipping resource template with unresolved includes", "file", d.Name(), "error", includeErrs[0])
+			for _, err := range includeErrs {
+				issues = append(issues, domain.NewError(domain.RuleInclude, path, "%v", err))
+			}
+			return nil
+		}
+
+		tmpl, err := template.New(uriTemplate).Option("missingkey=zero").Parse(body)
+		if err != nil {
+			slog.Warn("Skipping resource template with invalid template", "file", d.Name(), "error", err)
+			issues = append(issues, domain.NewError(domain.RuleTemplate, path, "%v", err))
+			return nil
+		}
+
+		relPath, err := filepath.Rel(resourcesDir, path)
+		if err != nil {
+			return err
+		}
+		templateToPath[uriTemplate] = filepath.ToSlash(relPath)
+
+		definitions = append(definitions, ResourceTemplateDefinition{
+			URITemplate: uriTemplate,
+			Name:        name,
+			Description: description,
+			MIMEType:    MIMETypeMarkdown,
+			FilePath:    path,
+			Template:    tmpl,
+		})
+
+		slog.Info("Loaded resource template", "uri_template", uriTemplate, "name", name)
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return definitions, issues, nil
+}
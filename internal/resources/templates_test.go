@@ -0,0 +1,182 @@
+package resources
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/sha1n/mcp-acdc-server/internal/content"
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+)
+
+func TestResourceProvider_Templates(t *testing.T) {
+	tmp := t.TempDir()
+	f := filepath.Join(tmp, "payments.md")
+	if err := os.WriteFile(f, []byte("---\nname: P\ndescription: D\n---\nPayments"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	p := NewResourceProvider(
+		[]ResourceDefinition{{URI: "acdc://runbooks/payments", Name: "P", FilePath: f}},
+		WithTemplates([]ResourceTemplateDefinition{
+			{URITemplate: "acdc://runbooks/{service}", Name: "Runbook", MIMEType: "text/markdown"},
+			{
+				URITemplate: "acdc://services/{name}",
+				Name:        "Service",
+				MIMEType:    "text/markdown",
+				FilePath:    filepath.Join(tmp, "_service.md"),
+				Template:    template.Must(template.New("t").Option("missingkey=zero").Parse("Service {{.name}}")),
+			},
+			{URITemplate: "acdc://broken/{", Name: "Broken"},
+		}),
+		WithTransformer(func(content string, def ResourceDefinition) string {
+			return content + " (" + def.URI + ")"
+		}),
+	)
+
+	t.Run("ListResourceTemplates", func(t *testing.T) {
+		list := p.ListResourceTemplates()
+		if len(list) != 2 {
+			t.Fatalf("Expected 2 templates (invalid one skipped), got %+v", list)
+		}
+		if list[0].URITemplate != "acdc://runbooks/{service}" || list[1].Name != "Service" {
+			t.Errorf("Unexpected templates: %+v", list)
+		}
+	})
+
+	t.Run("Discovered Resource Wins", func(t *testing.T) {
+		got, err := p.ReadResource("acdc://runbooks/payments")
+		if err != nil {
+			t.Fatalf("ReadResource error = %v", err)
+		}
+		if got != "Payments (acdc://runbooks/payments)" {
+			t.Errorf("ReadResource = %q", got)
+		}
+	})
+
+	t.Run("Metadata Template Does Not Render", func(t *testing.T) {
+		if _, err := p.ReadResource("acdc://runbooks/unknown"); err == nil {
+			t.Error("Expected error for undiscovered resource")
+		}
+	})
+
+	t.Run("Rendered Template", func(t *testing.T) {
+		got, err := p.ReadResource("acdc://services/checkout")
+		if err != nil {
+			t.Fatalf("ReadResource error = %v", err)
+		}
+		if got != "Service checkout (acdc://services/checkout)" {
+			t.Errorf("ReadResource = %q", got)
+		}
+	})
+}
+
+func TestTemplatesFromMetadata(t *testing.T) {
+	defs := TemplatesFromMetadata([]domain.ResourceTemplateMetadata{
+		{URITemplate: "acdc://runbooks/{service}", Name: "Runbook", Description: "D"},
+	})
+	if len(defs) != 1 {
+		t.Fatalf("Expected 1 definition, got %d", len(defs))
+	}
+	if defs[0].URITemplate != "acdc://runbooks/{service}" || defs[0].MIMEType != "text/markdown" || defs[0].Template != nil {
+		t.Errorf("Unexpected definition: %+v", defs[0])
+	}
+}
+
+func TestDiscoverResourceTemplatesWithIssues(t *testing.T) {
+	tmp := t.TempDir()
+	resDir := filepath.Join(tmp, "mcp-resources")
+	if err := os.MkdirAll(filepath.Join(resDir, "services"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"services/a.md":   "---\nname: A\ndescription: D\nuri_template: acdc://services/{name}\n---\n# {{.name}}",
+		"services/dup.md": "---\nname: Dup\ndescription: D\nuri_template: acdc://services/{name}\n---\nDup",
+		"no-desc.md":      "---\nname: N\nuri_template: acdc://x/{id}\n---\nBody",
+		"bad-uri.md":      "---\nname: B\ndescription: D\nuri_template: acdc://x/{id\n---\nBody",
+		"bad-tmpl.md":     "---\nname: T\ndescription: D\nuri_template: acdc://t/{id}\n---\n{{.id",
+		"plain.md":        "---\nname: Plain\ndescription: D\n---\nNot a template",
+		"no-fm.md":        "No frontmatter",
+	}
+	for name, body := range files {
+		if err := os.WriteFile(filepath.Join(resDir, name), []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cp := content.NewContentProvider(tmp)
+	defs, issues, err := DiscoverResourceTemplatesWithIssues(cp)
+	if err != nil {
+		t.Fatalf("DiscoverResourceTemplatesWithIssues error = %v", err)
+	}
+	if len(defs) != 1 || defs[0].URITemplate != "acdc://services/{name}" || defs[0].Template == nil {
+		t.Fatalf("Expected only the services template, got %+v", defs)
+	}
+
+	rules := make(map[string]string)
+	for _, issue := range issues {
+		rules[filepath.Base(issue.File)] = issue.Rule
+	}
+	want := map[string]string{
+		"dup.md":      domain.RuleDuplicateURI,
+		"no-desc.md":  domain.RuleRequiredField,
+		"bad-uri.md":  domain.RuleTemplate,
+		"bad-tmpl.md": domain.RuleTemplate,
+	}
+	if len(rules) != len(want) {
+		t.Errorf("Expected %d issues, got %+v", len(want), issues)
+	}
+	for file, rule := range want {
+		if rules[file] != rule {
+			t.Errorf("Expected %s issue for %s, got %q", rule, file, rules[file])
+		}
+	}
+
+	// Template files are not discovered as resources
+	resourceDefs, err := DiscoverResources(cp, "acdc")
+	if err != nil {
+		t.Fatalf("DiscoverResources error = %v", err)
+	}
+	for _, d := range resourceDefs {
+		if strings.HasPrefix(d.URI, "acdc://services/") {
+			t.Errorf("Template file discovered as resource: %s", d.URI)
+		}
+	}
+}
+
+func TestResourceProvider_CompleteTemplateArgument(t *testing.T) {
+	p := NewResourceProvider(
+		[]ResourceDefinition{
+			{URI: "acdc://runbooks/prod/payments"},
+			{URI: "acdc://runbooks/prod/search"},
+			{URI: "acdc://runbooks/staging/payments"},
+			{URI: "acdc://guides/start"},
+		},
+		WithTemplates([]ResourceTemplateDefinition{{URITemplate: "acdc://runbooks/{env}/{service}"}}),
+	)
+
+	values, err := p.CompleteTemplateArgument("acdc://runbooks/{env}/{service}", "service", "", nil)
+	if err != nil {
+		t.Fatalf("CompleteTemplateArgument error = %v", err)
+	}
+	if strings.Join(values, ",") != "payments,search" {
+		t.Errorf("Unexpected values: %v", values)
+	}
+
+	values, _ = p.CompleteTemplateArgument("acdc://runbooks/{env}/{service}", "service", "S", nil)
+	if strings.Join(values, ",") != "search" {
+		t.Errorf("Unexpected prefix-filtered values: %v", values)
+	}
+
+	values, _ = p.CompleteTemplateArgument("acdc://runbooks/{env}/{service}", "service", "", map[string]string{"env": "staging"})
+	if strings.Join(values, ",") != "payments" {
+		t.Errorf("Unexpected context-filtered values: %v", values)
+	}
+
+	if _, err := p.CompleteTemplateArgument("acdc://unknown/{x}", "x", "", nil); err == nil {
+		t.Error("Expected error for unknown template")
+	}
+}
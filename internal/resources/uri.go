@@ -0,0 +1,66 @@
+package resources
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// Frontmatter fields that override the URI derived from the file path
+const (
+	uriField = "uri"
+	idField  = "id"
+)
+
+// normalizeURI returns the URI of a metadata value that is either a full URI
+// or a URI path under the configured scheme
+func normalizeURI(value, scheme string) string {
+	if strings.Contains(value, "://") {
+		return value
+	}
+	return fmt.Sprintf("%s://%s", scheme, strings.Trim(filepath.ToSlash(value), "/"))
+}
+
+// metadataURI returns the URI set by the uri or id metadata field, or "" if
+// neither is set. uri is a full URI in the configured scheme or a URI path; id
+// is a URI path. Setting both, a value that is not a non-empty string, or a
+// value that is not a valid URI (e.g. with spaces or bad escapes) is an error.
+func metadataURI(metadata map[string]interface{}, scheme string) (string, error) {
+	rawURI, hasURI := metadata[uriField]
+	rawID, hasID := metadata[idField]
+	switch {
+	case hasURI && hasID:
+		return "", fmt.Errorf("%s and %s are mutually exclusive", uriField, idField)
+	case hasID:
+		id, _ := rawID.(string)
+		if strings.Trim(id, "/ ") == "" || strings.Contains(id, "://") {
+			return "", fmt.Errorf("%s must be a non-empty URI path without a scheme, got %v", idField, rawID)
+		}
+		return validURI(idField, id, normalizeURI(id, scheme))
+	case hasURI:
+		value, _ := rawURI.(string)
+		if strings.Trim(value, "/ ") == "" {
+			return "", fmt.Errorf("%s must be a non-empty URI or URI path, got %v", uriField, rawURI)
+		}
+		uri := normalizeURI(value, scheme)
+		if !strings.HasPrefix(uri, scheme+"://") || uri == scheme+"://" {
+			return "", fmt.Errorf("%s %q must use the %s:// scheme", uriField, value, scheme)
+		}
+		return validURI(uriField, value, uri)
+	}
+	return "", nil
+}
+
+// validURI returns uri, the URI of the value of field, if it parses as a URI
+// and contains no whitespace. The SDK cannot serve resources with such URIs.
+func validURI(field, value, uri string) (string, error) {
+	if strings.IndexFunc(uri, unicode.IsSpace) >= 0 {
+		return "", fmt.Errorf("%s %q must not contain whitespace", field, value)
+	}
+	if _, err := url.Parse(uri); err != nil {
+		return "", fmt.Errorf("%s %q is not a valid URI: %v", field, value, err)
+	}
+	return uri, nil
+}
@@ -0,0 +1,80 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/sha1n/mcp-acdc-server/internal/content"
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadataURI(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata map[string]interface{}
+		expected string
+		err      string
+	}{
+		{"None", map[string]interface{}{}, "", ""},
+		{"URI", map[string]interface{}{"uri": "acdc://runbooks/db"}, "acdc://runbooks/db", ""},
+		{"URIPath", map[string]interface{}{"uri": "/runbooks/db"}, "acdc://runbooks/db", ""},
+		{"ID", map[string]interface{}{"id": "db-runbook"}, "acdc://db-runbook", ""},
+		{"Both", map[string]interface{}{"uri": "a", "id": "b"}, "", "mutually exclusive"},
+		{"OtherScheme", map[string]interface{}{"uri": "other://x"}, "", "must use the acdc:// scheme"},
+		{"EmptyURI", map[string]interface{}{"uri": "/"}, "", "non-empty"},
+		{"NonStringID", map[string]interface{}{"id": 42}, "", "non-empty URI path"},
+		{"IDWithScheme", map[string]interface{}{"id": "acdc://x"}, "", "without a scheme"},
+		{"IDWithSpace", map[string]interface{}{"id": "my guide"}, "", "must not contain whitespace"},
+		{"URIPathWithSpace", map[string]interface{}{"uri": "guides/my guide"}, "", "must not contain whitespace"},
+		{"URIBadEscape", map[string]interface{}{"uri": "guides/a%zz"}, "", "is not a valid URI"},
+		{"IDBadEscape", map[string]interface{}{"id": "a%zz"}, "", "is not a valid URI"},
+		{"EscapedURI", map[string]interface{}{"uri": "guides/my%20guide"}, "acdc://guides/my%20guide", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uri, err := metadataURI(tt.metadata, "acdc")
+			if tt.err != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, uri)
+		})
+	}
+}
+
+func TestDiscoverResources_URIOverride(t *testing.T) {
+	tmp := writeResources(t, map[string]string{
+		"archive/2024/db-runbook.md": "---\nname: DB\ndescription: Database runbook\nid: runbooks/db\n---\nDB",
+		"ops/cache.md":               "---\nname: Cache\ndescription: Cache runbook\nuri: acdc://runbooks/cache\n---\nCache",
+		"runbooks/db.md":             "---\nname: Clash\ndescription: Derived URI clashes with an id\n---\nClash",
+		"z/dup.md":                   "---\nname: Dup\ndescription: Duplicate id\nid: runbooks/cache\n---\nDup",
+		"z/invalid.md":               "---\nname: Invalid\ndescription: Invalid override\nuri: other://x\n---\nInvalid",
+		"specs/api.json":             `{}`,
+		"specs/api.json.meta.yaml":   "name: API\ndescription: API spec\nid: api\n",
+	})
+
+	defs, issues, err := DiscoverResourcesWithIssues(content.NewContentProvider(tmp), "acdc")
+	require.NoError(t, err)
+
+	uris := make(map[string]string)
+	for _, d := range defs {
+		uris[d.URI] = d.Name
+	}
+	assert.Equal(t, map[string]string{
+		"acdc://runbooks/db":    "DB",
+		"acdc://runbooks/cache": "Cache",
+		"acdc://api":            "API",
+	}, uris)
+
+	require.Len(t, issues, 3)
+	assert.Equal(t, domain.RuleDuplicateURI, issues[0].Rule)
+	assert.Contains(t, issues[0].Message, "URI acdc://runbooks/db is already used by archive/2024/db-runbook.md")
+	assert.Equal(t, domain.RuleDuplicateURI, issues[1].Rule)
+	assert.Contains(t, issues[1].Message, "URI acdc://runbooks/cache is already used by ops/cache.md")
+	assert.Equal(t, domain.RuleFrontmatter, issues[2].Rule)
+	assert.Contains(t, issues[2].Message, "must use the acdc:// scheme")
+}
@@ -0,0 +1,145 @@
+package search
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	bsearch "github.com/blevesearch/bleve/v2/search"
+)
+
+// weightMessageRe matches Bleve term scorer explanations such as
+// "weight(name:auth^2.000000 in <doc>), product of:" and
+// "fieldWeight(content:auth in <doc>), as per tf-idf model, product of:".
+// It captures the field (group 1) and the matched index term (group 2).
+var weightMessageRe = regexp.MustCompile(`^(?:weight|fieldWeight)\(([^:\s]+):(\S+?)(?:\^\S+)? in `)
+
+// Explanation is a readable breakdown of how a search hit was scored
+type Explanation struct {
+	Score         float64             `json:"score"` // Text relevance before modifiers
+	FieldsMatched int                 `json:"fields_matched,omitempty"`
+	FieldsTotal   int                 `json:"fields_total,omitempty"`
+	Fields        []FieldContribution `json:"fields"`
+	Modifiers     []ScoreModifier     `json:"modifiers,omitempty"`
+	FinalScore    float64             `json:"final_score"` // Score after modifiers, used for ranking
+}
+
+// FieldContribution is the part of a hit's score contributed by a single field
+type FieldContribution struct {
+	Field string      `json:"field"`
+	Boost float64     `json:"boost"`
+	Score float64     `json:"score"`
+	Terms []TermMatch `json:"terms,omitempty"`
+}
+
+// TermMatch is an index term that matched the query within a field
+type TermMatch struct {
+	Term   string  `json:"term"`
+	Weight float64 `json:"weight"`
+	Fuzzy  bool    `json:"fuzzy,omitempty"`
+}
+
+// String renders the explanation as indented plain text
+func (e *Explanation) String() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("score %.4f", e.Score))
+	if e.FieldsTotal > 0 {
+		sb.WriteString(fmt.Sprintf(" (%d of %d fields matched)", e.FieldsMatched, e.FieldsTotal))
+	}
+	for _, f := range e.Fields {
+		sb.WriteString(fmt.Sprintf("\n  %s: %.4f (boost %.1f)", f.Field, f.Score, f.Boost))
+		if len(f.Terms) == 0 {
+			continue
+		}
+		terms := make([]string, len(f.Terms))
+		for i, t := range f.Terms {
+			terms[i] = fmt.Sprintf("%s=%.4f", t.Term, t.Weight)
+			if t.Fuzzy {
+				terms[i] += " (fuzzy)"
+			}
+		}
+		sb.WriteString(" terms: ")
+		sb.WriteString(strings.Join(terms, ", "))
+	}
+	if len(e.Modifiers) > 0 {
+		modifiers := make([]string, len(e.Modifiers))
+		for i, m := range e.Modifiers {
+			modifiers[i] = fmt.Sprintf("%s x%.4f", m.Name, m.Factor)
+		}
+		sb.WriteString(fmt.Sprintf("\n  modifiers: %s = %.4f", strings.Join(modifiers, ", "), e.FinalScore))
+	}
+	return sb.String()
+}
+
+// newExplanation converts a raw Bleve explanation tree into an Explanation.
+// boosts maps field names to their configured query-time boost, and
+// queryTerms holds the analyzed query terms used to tell exact matches from
+// fuzzy ones.
+func newExplanation(expl *bsearch.Explanation, boosts map[string]float64, queryTerms map[string]bool) *Explanation {
+	result := &Explanation{}
+	if expl == nil {
+		return result
+	}
+	result.Score = expl.Value
+	result.FinalScore = expl.Value
+
+	// Filtered queries wrap the scored query in a single-clause sum
+	for expl.Message == "sum of:" && len(expl.Children) == 1 {
+		expl = expl.Children[0]
+	}
+
+	// The top-level disjunction is explained as "product of: [sum of: [...], coord(n/m)]"
+	clauses := []*bsearch.Explanation{expl}
+	if len(expl.Children) == 2 {
+		var matched, total int
+		if _, err := fmt.Sscanf(expl.Children[1].Message, "coord(%d/%d)", &matched, &total); err == nil {
+			result.FieldsMatched = matched
+			result.FieldsTotal = total
+			clauses = expl.Children[0].Children
+		}
+	}
+
+	index := make(map[string]int)
+	for _, clause := range clauses {
+		var terms []TermMatch
+		field := collectTerms(clause, queryTerms, &terms)
+		if field == "" {
+			continue
+		}
+
+		i, ok := index[field]
+		if !ok {
+			i = len(result.Fields)
+			index[field] = i
+			result.Fields = append(result.Fields, FieldContribution{
+				Field: field,
+				Boost: boosts[field],
+			})
+		}
+		result.Fields[i].Score += clause.Value
+		result.Fields[i].Terms = append(result.Fields[i].Terms, terms...)
+	}
+
+	return result
+}
+
+// collectTerms walks an explanation subtree, appending every term match found
+// to terms and returning the field of the first match.
+func collectTerms(expl *bsearch.Explanation, queryTerms map[string]bool, terms *[]TermMatch) string {
+	if groups := weightMessageRe.FindStringSubmatch(expl.Message); groups != nil {
+		*terms = append(*terms, TermMatch{
+			Term:   groups[2],
+			Weight: expl.Value,
+			Fuzzy:  !queryTerms[groups[2]],
+		})
+		return groups[1]
+	}
+
+	field := ""
+	for _, child := range expl.Children {
+		if f := collectTerms(child, queryTerms, terms); field == "" {
+			field = f
+		}
+	}
+	return field
+}
@@ -0,0 +1,25 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FormatResults renders search results as a markdown list of links with
+// snippets, including score explanations when present
+func FormatResults(query string, results []SearchResult) string {
+	var sb strings.Builder
+	if len(results) == 0 {
+		sb.WriteString(fmt.Sprintf("No results found for '%s'", query))
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("Search results for '%s':\n\n", query))
+	for _, r := range results {
+		sb.WriteString(fmt.Sprintf("- [%s](%s): %s\n\n", r.Name, r.URI, r.Snippet))
+		if r.Explanation != nil {
+			sb.WriteString(fmt.Sprintf("```\n%s\n```\n\n", r.Explanation))
+		}
+	}
+	return sb.String()
+}
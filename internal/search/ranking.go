@@ -0,0 +1,82 @@
+package search
+
+import (
+	"math"
+	"time"
+
+	"github.com/sha1n/mcp-acdc-server/internal/config"
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+)
+
+// rerankWindow is how many times the requested number of results is fetched
+// from the index when score modifiers are enabled, so that documents promoted
+// by a modifier can still make it into the final results.
+const rerankWindow = 5
+
+// Score modifier names
+const (
+	ModifierPriority    = "priority"
+	ModifierRecency     = "recency"
+	ModifierDeprecation = "deprecation"
+)
+
+// ScoreModifier is a multiplicative factor applied to a hit's relevance score
+type ScoreModifier struct {
+	Name   string  `json:"name"`
+	Factor float64 `json:"factor"`
+}
+
+// modifiersEnabled reports whether any score modifier is configured
+func modifiersEnabled(settings config.SearchSettings) bool {
+	return settings.PriorityMultiplier > 0 || settings.RecencyHalfLifeDays > 0 || settings.DeprecationPenalty > 0
+}
+
+// scoreModifiers computes the modifiers that apply to a hit given its stored fields.
+// Modifiers with a neutral factor of 1 are omitted.
+func scoreModifiers(settings config.SearchSettings, fields map[string]interface{}, now time.Time) []ScoreModifier {
+	var modifiers []ScoreModifier
+
+	if settings.PriorityMultiplier > 0 {
+		if priority, ok := fields[domain.FieldPriority].(float64); ok && priority != 0 {
+			factor := math.Max(0, 1+settings.PriorityMultiplier*priority)
+			modifiers = append(modifiers, ScoreModifier{Name: ModifierPriority, Factor: factor})
+		}
+	}
+
+	if settings.RecencyHalfLifeDays > 0 {
+		if updated, ok := parseStoredTime(fields[domain.FieldUpdated]); ok {
+			ageDays := math.Max(0, now.Sub(updated).Hours()/24)
+			factor := math.Pow(0.5, ageDays/settings.RecencyHalfLifeDays)
+			modifiers = append(modifiers, ScoreModifier{Name: ModifierRecency, Factor: factor})
+		}
+	}
+
+	if settings.DeprecationPenalty > 0 {
+		if deprecated, ok := fields[domain.FieldDeprecated].(bool); ok && deprecated {
+			modifiers = append(modifiers, ScoreModifier{Name: ModifierDeprecation, Factor: 1 - settings.DeprecationPenalty})
+		}
+	}
+
+	return modifiers
+}
+
+// applyModifiers multiplies score by every modifier factor
+func applyModifiers(score float64, modifiers []ScoreModifier) float64 {
+	for _, m := range modifiers {
+		score *= m.Factor
+	}
+	return score
+}
+
+// parseStoredTime parses a datetime field value as returned by the index
+func parseStoredTime(v interface{}) (time.Time, bool) {
+	s, ok := v.(string)
+	if !ok || s == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
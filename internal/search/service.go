@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"sort"
+	"time"
 
 	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/keyword"
 	"github.com/blevesearch/bleve/v2/mapping"
 	"github.com/blevesearch/bleve/v2/search/query"
 	"github.com/sha1n/mcp-acdc-server/internal/config"
@@ -15,14 +18,27 @@ import (
 
 // SearchResult represents a search result
 type SearchResult struct {
-	URI     string
-	Name    string
-	Snippet string
+	URI         string
+	Name        string
+	Snippet     string
+	Score       float64
+	Explanation *Explanation // Set only when explanations are requested
 }
 
+// SearchOptions optional per-request search parameters
+type SearchOptions struct {
+	Limit     *int               // Overrides the configured maximum number of results when positive
+	URIPrefix string             // Only match resources whose URI starts with this prefix
+	Boosts    map[string]float64 // Per-field boost overrides keyed by field name
+	Explain   bool               // Include a score breakdown with each result
+}
+
+// BoostableFields lists the fields whose boost can be overridden per search
+var BoostableFields = []string{domain.FieldName, domain.FieldContent, domain.FieldKeywords}
+
 // Searcher interface in search package
 type Searcher interface {
-	Search(queryStr string, limit *int) ([]SearchResult, error)
+	Search(queryStr string, opts *SearchOptions) ([]SearchResult, error)
 	Index(ctx context.Context, documents <-chan domain.Document) error
 	Close()
 }
@@ -38,6 +54,7 @@ type Service struct {
 	settings config.SearchSettings
 	index    bleve.Index
 	indexDir string
+	now      func() time.Time
 }
 
 // Ensure Service implements Searcher
@@ -47,6 +64,7 @@ var _ Searcher = (*Service)(nil)
 func NewService(settings config.SearchSettings) *Service {
 	return &Service{
 		settings: settings,
+		now:      time.Now,
 	}
 }
 
@@ -130,9 +148,13 @@ func (s *Service) batchIndex(ctx context.Context, index BatchIndexer, documents
 	}
 }
 
+// textAnalyzer is the analyzer used for all searchable text fields
+const textAnalyzer = "en"
+
 func buildMapping() mapping.IndexMapping {
-	// URI field: Stored, Indexed
+	// URI field: Stored, Indexed as a single term to support prefix filtering
 	uriMapping := bleve.NewTextFieldMapping()
+	uriMapping.Analyzer = keyword.Name
 	uriMapping.Store = true
 	uriMapping.IncludeInAll = false
 
@@ -140,26 +162,45 @@ func buildMapping() mapping.IndexMapping {
 	nameMapping := bleve.NewTextFieldMapping()
 	nameMapping.Store = true
 	nameMapping.IncludeInAll = true
-	nameMapping.Analyzer = "en"
+	nameMapping.Analyzer = textAnalyzer
 
 	// Content field: Indexed, Not Stored, Included in All
 	contentMapping := bleve.NewTextFieldMapping()
 	contentMapping.Store = true // DEBUG: Store content to ensure we can see it
 	contentMapping.IncludeInAll = true
-	contentMapping.Analyzer = "en"
+	contentMapping.Analyzer = textAnalyzer
 
 	// Keywords field: Indexed, Not Stored, Included in All
 	// Boosting is done at query-time via DisjunctionQuery
 	keywordsMapping := bleve.NewTextFieldMapping()
 	keywordsMapping.Store = false
 	keywordsMapping.IncludeInAll = true
-	keywordsMapping.Analyzer = "en"
+	keywordsMapping.Analyzer = textAnalyzer
+
+	// Ranking fields: Stored only, read back to apply score modifiers
+	priorityMapping := bleve.NewNumericFieldMapping()
+	priorityMapping.Store = true
+	priorityMapping.Index = false
+	priorityMapping.IncludeInAll = false
+
+	updatedMapping := bleve.NewDateTimeFieldMapping()
+	updatedMapping.Store = true
+	updatedMapping.Index = false
+	updatedMapping.IncludeInAll = false
+
+	deprecatedMapping := bleve.NewBooleanFieldMapping()
+	deprecatedMapping.Store = true
+	deprecatedMapping.Index = false
+	deprecatedMapping.IncludeInAll = false
 
 	docMapping := bleve.NewDocumentMapping()
 	docMapping.AddFieldMappingsAt(domain.FieldURI, uriMapping)
 	docMapping.AddFieldMappingsAt(domain.FieldName, nameMapping)
 	docMapping.AddFieldMappingsAt(domain.FieldContent, contentMapping)
 	docMapping.AddFieldMappingsAt(domain.FieldKeywords, keywordsMapping)
+	docMapping.AddFieldMappingsAt(domain.FieldPriority, priorityMapping)
+	docMapping.AddFieldMappingsAt(domain.FieldUpdated, updatedMapping)
+	docMapping.AddFieldMappingsAt(domain.FieldDeprecated, deprecatedMapping)
 
 	mapping := bleve.NewIndexMapping()
 	mapping.DefaultMapping = docMapping
@@ -167,14 +208,24 @@ func buildMapping() mapping.IndexMapping {
 }
 
 // Search searches for resources
-func (s *Service) Search(queryStr string, limit *int) ([]SearchResult, error) {
+func (s *Service) Search(queryStr string, opts *SearchOptions) ([]SearchResult, error) {
 	if s.index == nil {
 		return []SearchResult{}, nil
 	}
 
+	if opts == nil {
+		opts = &SearchOptions{}
+	}
+
 	maxResults := s.settings.MaxResults
-	if limit != nil {
-		maxResults = *limit
+	if opts.Limit != nil && *opts.Limit > 0 {
+		maxResults = *opts.Limit
+	}
+	explain := opts.Explain || s.settings.Explain
+
+	boosts, err := s.fieldBoosts(opts.Boosts)
+	if err != nil {
+		return nil, err
 	}
 
 	// Build query with keyword boosting
@@ -187,32 +238,60 @@ func (s *Service) Search(queryStr string, limit *int) ([]SearchResult, error) {
 		nameQuery := bleve.NewMatchQuery(queryStr)
 		nameQuery.SetField(domain.FieldName)
 		nameQuery.SetFuzziness(1)
-		nameQuery.SetBoost(s.settings.NameBoost)
+		nameQuery.SetBoost(boosts[domain.FieldName])
 
 		contentQuery := bleve.NewMatchQuery(queryStr)
 		contentQuery.SetField(domain.FieldContent)
 		contentQuery.SetFuzziness(1)
-		contentQuery.SetBoost(s.settings.ContentBoost)
+		contentQuery.SetBoost(boosts[domain.FieldContent])
 
 		keywordsQuery := bleve.NewMatchQuery(queryStr)
 		keywordsQuery.SetField(domain.FieldKeywords)
 		keywordsQuery.SetFuzziness(1)
-		keywordsQuery.SetBoost(s.settings.KeywordsBoost)
+		keywordsQuery.SetBoost(boosts[domain.FieldKeywords])
 
 		// DisjunctionQuery combines results, boosted fields will score higher
 		q = bleve.NewDisjunctionQuery(nameQuery, contentQuery, keywordsQuery)
 	}
 
+	// Filter by URI prefix without affecting scores
+	if opts.URIPrefix != "" {
+		prefixQuery := bleve.NewPrefixQuery(opts.URIPrefix)
+		prefixQuery.SetField(domain.FieldURI)
+
+		filtered := bleve.NewBooleanQuery()
+		filtered.AddMust(q)
+		filtered.AddFilter(prefixQuery)
+		q = filtered
+	}
+
+	// Fetch extra candidates when modifiers may reorder the hits
+	rerank := modifiersEnabled(s.settings)
+	size := maxResults
+	if rerank {
+		size = maxResults * rerankWindow
+	}
+
 	searchRequest := bleve.NewSearchRequest(q)
-	searchRequest.Size = maxResults
-	searchRequest.Fields = []string{domain.FieldURI, domain.FieldName, domain.FieldContent}
+	searchRequest.Size = size
+	searchRequest.Fields = []string{
+		domain.FieldURI, domain.FieldName, domain.FieldContent,
+		domain.FieldPriority, domain.FieldUpdated, domain.FieldDeprecated,
+	}
 	searchRequest.Highlight = bleve.NewHighlight()
+	searchRequest.Explain = explain
 
 	searchResult, err := s.index.Search(searchRequest)
 	if err != nil {
 		return nil, fmt.Errorf("search failed: %w", err)
 	}
 
+	var queryTerms map[string]bool
+	if explain {
+		queryTerms = s.analyzeQuery(queryStr)
+	}
+
+	now := s.now()
 	results := make([]SearchResult, 0, len(searchResult.Hits))
 	for _, hit := range searchResult.Hits {
 		uri, ok := hit.Fields[domain.FieldURI].(string)
@@ -226,22 +305,80 @@ func (s *Service) Search(queryStr string, limit *int) ([]SearchResult, error) {
 			name = "Unknown" // Fallback
 		}
 
+		score := hit.Score
+		var modifiers []ScoreModifier
+		if rerank {
+			modifiers = scoreModifiers(s.settings, hit.Fields, now)
+			score = applyModifiers(score, modifiers)
+		}
+
 		// Improved snippet generation with highlighting
-		snippet := fmt.Sprintf("%s (relevance: %.2f)", name, hit.Score)
+		snippet := fmt.Sprintf("%s (relevance: %.2f)", name, score)
 		if fragments, ok := hit.Fragments[domain.FieldContent]; ok && len(fragments) > 0 {
-			snippet = fmt.Sprintf("%s... (relevance: %.2f)", fragments[0], hit.Score)
+			snippet = fmt.Sprintf("%s... (relevance: %.2f)", fragments[0], score)
 		}
 
-		results = append(results, SearchResult{
+		result := SearchResult{
 			URI:     uri,
 			Name:    name,
 			Snippet: snippet,
+			Score:   score,
+		}
+		if explain {
+			result.Explanation = newExplanation(hit.Expl, boosts, queryTerms)
+			result.Explanation.Modifiers = modifiers
+			result.Explanation.FinalScore = score
+		}
+
+		results = append(results, result)
+	}
+
+	if rerank {
+		sort.SliceStable(results, func(i, j int) bool {
+			return results[i].Score > results[j].Score
 		})
+		if len(results) > maxResults {
+			results = results[:maxResults]
+		}
 	}
 
 	return results, nil
 }
 
+// fieldBoosts returns the query-time boost of each searched field, applying
+// any per-search overrides on top of the configured boosts.
+func (s *Service) fieldBoosts(overrides map[string]float64) (map[string]float64, error) {
+	boosts := map[string]float64{
+		domain.FieldName:     s.settings.NameBoost,
+		domain.FieldContent:  s.settings.ContentBoost,
+		domain.FieldKeywords: s.settings.KeywordsBoost,
+	}
+	for field, boost := range overrides {
+		if _, ok := boosts[field]; !ok {
+			return nil, fmt.Errorf("unknown boost field: %s (expected one of %v)", field, BoostableFields)
+		}
+		if boost < 0 {
+			return nil, fmt.Errorf("boost for %s must not be negative", field)
+		}
+		boosts[field] = boost
+	}
+	return boosts, nil
+}
+
+// analyzeQuery returns the set of terms the query produces after analysis,
+// which is how the index sees them (e.g. stemmed and lowercased).
+func (s *Service) analyzeQuery(queryStr string) map[string]bool {
+	terms := make(map[string]bool)
+	analyzer := s.index.Mapping().AnalyzerNamed(textAnalyzer)
+	if analyzer == nil {
+		return terms
+	}
+	for _, token := range analyzer.Analyze([]byte(queryStr)) {
+		terms[string(token.Term)] = true
+	}
+	return terms
+}
+
 // Close cleans up resources
 func (s *Service) Close() {
 	if s.index != nil {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/blevesearch/bleve/v2"
 	"github.com/sha1n/mcp-acdc-server/internal/config"
@@ -344,7 +345,7 @@ func TestSearchService_Extended(t *testing.T) {
 	// 2. Test MaxResults and Limits
 	// Default from settings is 5, request explicit limit 1
 	limit := 1
-	results, err = service.Search("*", &limit)
+	results, err = service.Search("*", &SearchOptions{Limit: &limit})
 	if err != nil {
 		t.Fatalf("Search with limit failed: %v", err)
 	}
@@ -361,6 +362,17 @@ func TestSearchService_Extended(t *testing.T) {
 		t.Errorf("Expected 3 results (within MaxResults=5), got %d", len(results))
 	}
 
+	// Test non-positive limits use MaxResults
+	for _, limit := range []int{0, -5} {
+		results, err = service.Search("*", &SearchOptions{Limit: &limit})
+		if err != nil {
+			t.Fatalf("Search with limit %d failed: %v", limit, err)
+		}
+		if len(results) != 3 {
+			t.Errorf("Expected 3 results with limit=%d, got %d", limit, len(results))
+		}
+	}
+
 	// 3. Test Result fields (Snippet, URI, Name)
 	// Searching for "Alpha" should return doc 1
 	results, err = service.Search("Alpha", nil)
@@ -687,3 +699,293 @@ func TestSearch_KeywordsOnlyMatch(t *testing.T) {
 		t.Errorf("Expected acdc://guide, got %s", results[0].URI)
 	}
 }
+
+func TestSearch_Explain(t *testing.T) {
+	settings := testSettings()
+	settings.InMemory = true
+	service := NewService(settings)
+	defer service.Close()
+
+	docs := []domain.Document{
+		{
+			URI:      "acdc://auth",
+			Name:     "Auth Guide",
+			Content:  "How to configure authentication",
+			Keywords: []string{"auth", "security"},
+		},
+	}
+	if err := indexDocsHelper(service, docs); err != nil {
+		t.Fatalf("IndexDocuments failed: %v", err)
+	}
+
+	t.Run("Disabled by default", func(t *testing.T) {
+		results, err := service.Search("auth", nil)
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		if len(results) != 1 {
+			t.Fatalf("Expected 1 result, got %d", len(results))
+		}
+		if results[0].Explanation != nil {
+			t.Errorf("Expected no explanation, got %+v", results[0].Explanation)
+		}
+	})
+
+	t.Run("Per-field breakdown", func(t *testing.T) {
+		results, err := service.Search("auth", &SearchOptions{Explain: true})
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		if len(results) != 1 {
+			t.Fatalf("Expected 1 result, got %d", len(results))
+		}
+
+		expl := results[0].Explanation
+		if expl == nil {
+			t.Fatal("Expected explanation")
+		}
+		if expl.Score != results[0].Score {
+			t.Errorf("Expected explanation score %f to equal hit score %f", expl.Score, results[0].Score)
+		}
+		if expl.FieldsMatched != 2 || expl.FieldsTotal != 3 {
+			t.Errorf("Expected 2 of 3 fields matched, got %d of %d", expl.FieldsMatched, expl.FieldsTotal)
+		}
+
+		fields := make(map[string]FieldContribution)
+		for _, f := range expl.Fields {
+			fields[f.Field] = f
+		}
+		name, ok := fields[domain.FieldName]
+		if !ok {
+			t.Fatalf("Expected name contribution, got %+v", expl.Fields)
+		}
+		if name.Boost != settings.NameBoost {
+			t.Errorf("Expected name boost %f, got %f", settings.NameBoost, name.Boost)
+		}
+		keywords, ok := fields[domain.FieldKeywords]
+		if !ok {
+			t.Fatalf("Expected keywords contribution, got %+v", expl.Fields)
+		}
+		if len(keywords.Terms) != 1 || keywords.Terms[0].Term != "auth" || keywords.Terms[0].Fuzzy {
+			t.Errorf("Expected exact keyword term 'auth', got %+v", keywords.Terms)
+		}
+		if _, ok := fields[domain.FieldContent]; ok {
+			t.Errorf("Did not expect a content contribution")
+		}
+	})
+
+	t.Run("Fuzzy match", func(t *testing.T) {
+		results, err := service.Search("aith", &SearchOptions{Explain: true})
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		if len(results) != 1 {
+			t.Fatalf("Expected 1 result, got %d", len(results))
+		}
+
+		var fuzzy bool
+		for _, f := range results[0].Explanation.Fields {
+			for _, term := range f.Terms {
+				fuzzy = fuzzy || term.Fuzzy
+			}
+		}
+		if !fuzzy {
+			t.Errorf("Expected a fuzzy term match, got %+v", results[0].Explanation.Fields)
+		}
+		if !contains(results[0].Explanation.String(), "(fuzzy)") {
+			t.Errorf("Expected rendered explanation to mark fuzzy terms, got %s", results[0].Explanation)
+		}
+	})
+
+	t.Run("Enabled by settings", func(t *testing.T) {
+		explainSettings := settings
+		explainSettings.Explain = true
+		s := NewService(explainSettings)
+		defer s.Close()
+		if err := indexDocsHelper(s, docs); err != nil {
+			t.Fatalf("IndexDocuments failed: %v", err)
+		}
+
+		results, err := s.Search("auth", nil)
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		if len(results) != 1 || results[0].Explanation == nil {
+			t.Errorf("Expected an explained result, got %+v", results)
+		}
+	})
+}
+
+func TestSearch_ScoreModifiers(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	recent := now.AddDate(0, 0, -10)
+	stale := now.AddDate(-2, 0, 0)
+
+	// Identical text so that only modifiers decide the ranking
+	docs := []domain.Document{
+		{URI: "acdc://stale", Name: "Deploy Guide", Content: "deployment steps", Updated: &stale},
+		{URI: "acdc://deprecated", Name: "Deploy Guide", Content: "deployment steps", Deprecated: true},
+		{URI: "acdc://priority", Name: "Deploy Guide", Content: "deployment steps", Priority: 2},
+		{URI: "acdc://recent", Name: "Deploy Guide", Content: "deployment steps", Updated: &recent},
+	}
+
+	newService := func(settings config.SearchSettings) *Service {
+		settings.InMemory = true
+		s := NewService(settings)
+		s.now = func() time.Time { return now }
+		if err := indexDocsHelper(s, docs); err != nil {
+			t.Fatalf("IndexDocuments failed: %v", err)
+		}
+		return s
+	}
+
+	t.Run("Disabled by default", func(t *testing.T) {
+		s := newService(testSettings())
+		defer s.Close()
+
+		results, err := s.Search("deployment", nil)
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		if len(results) != 4 {
+			t.Fatalf("Expected 4 results, got %d", len(results))
+		}
+		for _, r := range results[1:] {
+			if r.Score != results[0].Score {
+				t.Errorf("Expected equal scores without modifiers, got %f and %f", results[0].Score, r.Score)
+			}
+		}
+	})
+
+	t.Run("Applied and reranked", func(t *testing.T) {
+		settings := testSettings()
+		settings.PriorityMultiplier = 0.5
+		settings.RecencyHalfLifeDays = 365
+		settings.DeprecationPenalty = 0.9
+		s := newService(settings)
+		defer s.Close()
+
+		results, err := s.Search("deployment", &SearchOptions{Explain: true})
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+
+		var order []string
+		for _, r := range results {
+			order = append(order, r.URI)
+		}
+		expected := []string{"acdc://priority", "acdc://recent", "acdc://stale", "acdc://deprecated"}
+		if fmt.Sprint(order) != fmt.Sprint(expected) {
+			t.Fatalf("Expected order %v, got %v", expected, order)
+		}
+
+		priority := results[0].Explanation
+		if len(priority.Modifiers) != 1 || priority.Modifiers[0].Name != ModifierPriority || priority.Modifiers[0].Factor != 2 {
+			t.Errorf("Expected priority modifier x2, got %+v", priority.Modifiers)
+		}
+		if priority.FinalScore != results[0].Score || priority.FinalScore != priority.Score*2 {
+			t.Errorf("Expected final score to be twice the text score, got %f and %f", priority.FinalScore, priority.Score)
+		}
+
+		stale := results[2].Explanation
+		if len(stale.Modifiers) != 1 || stale.Modifiers[0].Name != ModifierRecency || stale.Modifiers[0].Factor > 0.26 {
+			t.Errorf("Expected recency modifier of about 0.25 for a two year old document, got %+v", stale.Modifiers)
+		}
+	})
+
+	t.Run("Limit applies after reranking", func(t *testing.T) {
+		settings := testSettings()
+		settings.DeprecationPenalty = 0.5
+		s := newService(settings)
+		defer s.Close()
+
+		limit := 3
+		results, err := s.Search("deployment", &SearchOptions{Limit: &limit})
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		if len(results) != 3 {
+			t.Fatalf("Expected 3 results, got %d", len(results))
+		}
+		for _, r := range results {
+			if r.URI == "acdc://deprecated" {
+				t.Errorf("Expected deprecated resource to be ranked out of the top 3")
+			}
+		}
+
+		limit = -1
+		if _, err := s.Search("deployment", &SearchOptions{Limit: &limit}); err != nil {
+			t.Fatalf("Search with negative limit failed: %v", err)
+		}
+	})
+}
+
+func TestSearch_URIPrefix(t *testing.T) {
+	settings := testSettings()
+	settings.InMemory = true
+	service := NewService(settings)
+	defer service.Close()
+
+	docs := []domain.Document{
+		{URI: "acdc://guides/deploy", Name: "Deploy Guide", Content: "deployment steps"},
+		{URI: "acdc://guides/rollback", Name: "Rollback Guide", Content: "deployment rollback"},
+		{URI: "acdc://reference/deploy", Name: "Deploy Reference", Content: "deployment options"},
+	}
+	if err := indexDocsHelper(service, docs); err != nil {
+		t.Fatalf("IndexDocuments failed: %v", err)
+	}
+
+	results, err := service.Search("deployment", &SearchOptions{URIPrefix: "acdc://guides/", Explain: true})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	for _, r := range results {
+		if r.URI != "acdc://guides/deploy" && r.URI != "acdc://guides/rollback" {
+			t.Errorf("Unexpected result outside prefix: %s", r.URI)
+		}
+		if r.Explanation == nil || len(r.Explanation.Fields) == 0 {
+			t.Errorf("Expected field contributions for filtered result, got %+v", r.Explanation)
+		}
+	}
+}
+
+func TestSearch_BoostOverrides(t *testing.T) {
+	settings := testSettings()
+	settings.InMemory = true
+	service := NewService(settings)
+	defer service.Close()
+
+	docs := []domain.Document{
+		{URI: "acdc://by-name", Name: "Kubernetes", Content: "cluster overview"},
+		{URI: "acdc://by-content", Name: "Overview", Content: "kubernetes kubernetes kubernetes"},
+	}
+	if err := indexDocsHelper(service, docs); err != nil {
+		t.Fatalf("IndexDocuments failed: %v", err)
+	}
+
+	results, err := service.Search("kubernetes", &SearchOptions{Boosts: map[string]float64{"name": 0, "content": 10}})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) == 0 || results[0].URI != "acdc://by-content" {
+		t.Errorf("Expected content match to rank first with content boost, got %+v", results)
+	}
+
+	results, err = service.Search("kubernetes", &SearchOptions{Boosts: map[string]float64{"name": 10, "content": 0.1}})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) == 0 || results[0].URI != "acdc://by-name" {
+		t.Errorf("Expected name match to rank first with name boost, got %+v", results)
+	}
+
+	if _, err := service.Search("kubernetes", &SearchOptions{Boosts: map[string]float64{"title": 2}}); err == nil {
+		t.Error("Expected error for unknown boost field")
+	}
+	if _, err := service.Search("kubernetes", &SearchOptions{Boosts: map[string]float64{"name": -1}}); err == nil {
+		t.Error("Expected error for negative boost")
+	}
+}
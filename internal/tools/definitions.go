@@ -0,0 +1,132 @@
+package tools
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"text/template"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+	"github.com/sha1n/mcp-acdc-server/internal/prompts"
+)
+
+// defaultQueryTemplate is the query of search tools that do not declare one
+const defaultQueryTemplate = "{{.query}}"
+
+// errInvalidTemplate is wrapped by errors for tool templates that fail to parse
+var errInvalidTemplate = errors.New("invalid template")
+
+// ToolDefinition represents a custom tool defined in content
+type ToolDefinition struct {
+	Name        string
+	Description string
+	Kind        string // One of the domain.ToolKind constants
+	InputSchema *jsonschema.Schema
+	URIPrefix   string // Search scope of search tools
+	Limit       int    // Maximum results of search tools, 0 for the server default
+	FilePath    string // Empty for tools declared in mcp-metadata.yaml
+	Annotations *domain.ToolAnnotations
+
+	// Template renders the response of template tools, the query of search
+	// tools and the resource URI of read tools
+	Template *template.Template
+}
+
+// newDefinition builds a tool definition from its metadata. body is the
+// response template of template tools defined in mcp-tools/ and overrides
+// the template field.
+func newDefinition(meta domain.ToolMetadata, body, filePath string) (ToolDefinition, error) {
+	if body != "" {
+		meta.Template = body
+	}
+	if err := meta.ValidateCustomTool(); err != nil {
+		return ToolDefinition{}, err
+	}
+
+	schema, err := inputSchema(meta)
+	if err != nil {
+		return ToolDefinition{}, fmt.Errorf("custom tool %q has an invalid input_schema: %w", meta.Name, err)
+	}
+
+	var text string
+	switch meta.Kind {
+	case domain.ToolKindTemplate:
+		text = meta.Template
+	case domain.ToolKindSearch:
+		text = meta.Query
+		if text == "" {
+			text = defaultQueryTemplate
+		}
+	case domain.ToolKindRead:
+		text = meta.URI
+	}
+	tmpl, err := template.New(meta.Name).Option("missingkey=zero").Funcs(prompts.NewFuncMap(nil, nil)).Parse(text)
+	if err != nil {
+		return ToolDefinition{}, fmt.Errorf("custom tool %q has an %w: %v", meta.Name, errInvalidTemplate, err)
+	}
+
+	return ToolDefinition{
+		Name:        meta.Name,
+		Description: meta.Description,
+		Kind:        meta.Kind,
+		InputSchema: schema,
+		URIPrefix:   meta.URIPrefix,
+		Limit:       meta.Limit,
+		FilePath:    filePath,
+		Annotations: meta.Annotations,
+		Template:    tmpl,
+	}, nil
+}
+
+// inputSchema converts the declared input schema of a tool. Search tools
+// without a schema take a single required query argument; other tools take
+// no arguments.
+func inputSchema(meta domain.ToolMetadata) (*jsonschema.Schema, error) {
+	if meta.InputSchema == nil {
+		schema := &jsonschema.Schema{Type: "object"}
+		if meta.Kind == domain.ToolKindSearch {
+			schema.Properties = map[string]*jsonschema.Schema{
+				"query": {Type: "string", Description: "The search query. Use natural language or keywords."},
+			}
+			schema.Required = []string{"query"}
+		}
+		return schema, nil
+	}
+
+	data, err := json.Marshal(meta.InputSchema)
+	if err != nil {
+		return nil, err
+	}
+	var schema jsonschema.Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, err
+	}
+	if schema.Type == "" {
+		schema.Type = "object"
+	}
+	if schema.Type != "object" {
+		return nil, fmt.Errorf("type must be object, got %s", schema.Type)
+	}
+	if _, err := schema.Resolve(nil); err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
+
+// FromMetadata builds the custom tools declared in mcp-metadata.yaml. Entries
+// without a kind configure built-in tools and are ignored, as are disabled tools.
+func FromMetadata(entries []domain.ToolMetadata) ([]ToolDefinition, error) {
+	var definitions []ToolDefinition
+	for _, meta := range entries {
+		if !meta.IsCustom() || !meta.IsEnabled() {
+			continue
+		}
+		defn, err := newDefinition(meta, "", "")
+		if err != nil {
+			return nil, err
+		}
+		definitions = append(definitions, defn)
+	}
+	return definitions, nil
+}
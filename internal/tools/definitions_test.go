@@ -0,0 +1,64 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromMetadata(t *testing.T) {
+	defs, err := FromMetadata([]domain.ToolMetadata{
+		{Name: "search", Description: "Override of the built-in tool"},
+		{Name: "checklist", Description: "Checklist", Kind: domain.ToolKindTemplate, Template: "- {{.area}}",
+			InputSchema: map[string]interface{}{
+				"properties": map[string]interface{}{"area": map[string]interface{}{"type": "string"}},
+				"required":   []interface{}{"area"},
+			}},
+		{Name: "lookup", Description: "Lookup", Kind: domain.ToolKindSearch, URIPrefix: "acdc://runbooks/", Limit: 3},
+		{Name: "runbook", Description: "Runbook", Kind: domain.ToolKindRead, URI: "acdc://runbooks/{{.service}}"},
+	})
+	require.NoError(t, err)
+	require.Len(t, defs, 3, "overrides of built-in tools are not custom tools")
+
+	checklist := defs[0]
+	assert.Equal(t, "checklist", checklist.Name)
+	assert.Equal(t, "object", checklist.InputSchema.Type, "object type is implied")
+	assert.Equal(t, []string{"area"}, checklist.InputSchema.Required)
+	assert.Equal(t, "string", checklist.InputSchema.Properties["area"].Type)
+	assert.Empty(t, checklist.FilePath)
+
+	lookup := defs[1]
+	assert.Equal(t, []string{"query"}, lookup.InputSchema.Required, "search tools take a query by default")
+	assert.Equal(t, "acdc://runbooks/", lookup.URIPrefix)
+	assert.Equal(t, 3, lookup.Limit)
+
+	runbook := defs[2]
+	assert.Equal(t, "object", runbook.InputSchema.Type)
+	assert.Empty(t, runbook.InputSchema.Properties)
+}
+
+func TestFromMetadata_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		tool   domain.ToolMetadata
+		errMsg string
+	}{
+		{"UnsupportedKind", domain.ToolMetadata{Name: "t", Kind: "exec"}, "unsupported kind"},
+		{"InvalidTemplate", domain.ToolMetadata{Name: "t", Kind: domain.ToolKindTemplate, Template: "{{.area"}, "invalid template"},
+		{"UnknownFunction", domain.ToolMetadata{Name: "t", Kind: domain.ToolKindRead, URI: "{{nope .x}}"}, "invalid template"},
+		{"NonObjectSchema", domain.ToolMetadata{Name: "t", Kind: domain.ToolKindTemplate, Template: "x",
+			InputSchema: map[string]interface{}{"type": "string"}}, "invalid input_schema"},
+		{"UnresolvableSchema", domain.ToolMetadata{Name: "t", Kind: domain.ToolKindTemplate, Template: "x",
+			InputSchema: map[string]interface{}{"$ref": "#/missing"}}, "invalid input_schema"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := FromMetadata([]domain.ToolMetadata{tt.tool})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
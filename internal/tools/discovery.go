@@ -0,0 +1,138 @@
+package tools
+
+import (
+	"errors"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sha1n/mcp-acdc-server/internal/content"
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+	"gopkg.in/yaml.v3"
+)
+
+// Origins reported for tool names that are already used
+const (
+	metadataFileName = "mcp-metadata.yaml"
+	builtinToolName  = "a built-in tool"
+)
+
+// DiscoverTools discovers custom tools from markdown files in mcp-tools/.
+// Invalid files are logged and skipped.
+func DiscoverTools(cp *content.ContentProvider, declared []ToolDefinition, builtins []string) ([]ToolDefinition, error) {
+	definitions, _, err := DiscoverToolsWithIssues(cp, declared, builtins)
+	return definitions, err
+}
+
+// DiscoverToolsWithIssues discovers tools like DiscoverTools and also returns
+// an issue for every file that was skipped. Tools with an invalid name, or a
+// name used by a declared tool or by one of the builtins (the names the
+// built-in tools are exposed as), are skipped.
+func DiscoverToolsWithIssues(cp *content.ContentProvider, declared []ToolDefinition, builtins []string) ([]ToolDefinition, []domain.Issue, error) {
+	var definitions []ToolDefinition
+	var issues []domain.Issue
+	nameToPath := make(map[string]string)
+	for _, name := range builtins {
+		nameToPath[name] = builtinToolName
+	}
+	for _, d := range declared {
+		nameToPath[d.Name] = metadataFileName
+	}
+	toolsDir := cp.ToolsDir
+
+	// Ensure directory exists, if not just return empty
+	if _, err := os.Stat(toolsDir); err != nil {
+		if os.IsNotExist(err) {
+			slog.Debug("Tools directory does not exist", "path", toolsDir)
+			return nil, nil, nil
+		}
+		slog.Error("Failed to access tools directory", "path", toolsDir, "error", err)
+		return nil, nil, err
+	}
+
+	err := filepath.WalkDir(toolsDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			slog.Error("Error walking tools directory", "path", path, "error", err)
+			return nil // continue walking
+		}
+		if d.IsDir() || filepath.Ext(path) != ".md" {
+			return nil
+		}
+
+		md, err := cp.LoadMarkdownWithFrontmatter(path)
+		if err != nil {
+			slog.Warn("Skipping invalid tool file", "file", d.Name(), "error", err)
+			issues = append(issues, domain.NewError(domain.RuleFrontmatter, path, "%v", err))
+			return nil
+		}
+
+		if missing := md.MissingFields("name", "description", "kind"); len(missing) > 0 {
+			slog.Warn("Skipping tool with missing metadata", "file", d.Name())
+			issues = append(issues, domain.NewError(domain.RuleRequiredField, path, "missing required frontmatter field(s): %s", strings.Join(missing, ", ")))
+			return nil
+		}
+
+		meta, err := decodeToolMetadata(md.Metadata)
+		if err != nil {
+			slog.Warn("Skipping tool with invalid metadata", "file", d.Name(), "error", err)
+			issues = append(issues, domain.NewError(domain.RuleFrontmatter, path, "%v", err))
+			return nil
+		}
+
+		if !meta.IsEnabled() {
+			slog.Info("Skipping disabled tool", "file", d.Name(), "name", meta.Name)
+			return nil
+		}
+
+		if err := domain.ValidateToolName(meta.Name); err != nil {
+			slog.Warn("Skipping tool with invalid name", "file", d.Name(), "error", err)
+			issues = append(issues, domain.NewError(domain.RuleFrontmatter, path, "%v", err))
+			return nil
+		}
+
+		if existing, ok := nameToPath[meta.Name]; ok {
+			slog.Warn("Skipping tool with duplicate name", "file", d.Name(), "name", meta.Name)
+			issues = append(issues, domain.NewError(domain.RuleDuplicateTool, path, "tool name %q is already used by %s", meta.Name, existing))
+			return nil
+		}
+
+		var body string
+		if meta.Kind == domain.ToolKindTemplate {
+			body = strings.TrimSpace(md.Content)
+		}
+		defn, err := newDefinition(meta, body, path)
+		if err != nil {
+			slog.Warn("Skipping invalid tool", "file", d.Name(), "error", err)
+			rule := domain.RuleFrontmatter
+			if errors.Is(err, errInvalidTemplate) {
+				rule = domain.RuleTemplate
+			}
+			issues = append(issues, domain.NewError(rule, path, "%v", err))
+			return nil
+		}
+
+		// path is within toolsDir, so Rel cannot fail
+		rel, _ := filepath.Rel(toolsDir, path)
+		nameToPath[meta.Name] = filepath.ToSlash(rel)
+		definitions = append(definitions, defn)
+
+		slog.Info("Loaded tool", "name", meta.Name)
+
+		return nil
+	})
+
+	return definitions, issues, err
+}
+
+// decodeToolMetadata decodes frontmatter into tool metadata
+func decodeToolMetadata(frontmatter map[string]interface{}) (domain.ToolMetadata, error) {
+	var meta domain.ToolMetadata
+	data, err := yaml.Marshal(frontmatter)
+	if err != nil {
+		return meta, err
+	}
+	err = yaml.Unmarshal(data, &meta)
+	return meta, err
+}
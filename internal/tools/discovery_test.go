@@ -0,0 +1,147 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sha1n/mcp-acdc-server/internal/content"
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeToolFiles(t *testing.T, files map[string]string) *content.ContentProvider {
+	t.Helper()
+	tempDir := t.TempDir()
+	for name, body := range files {
+		path := filepath.Join(tempDir, "mcp-tools", filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(body), 0644))
+	}
+	return content.NewContentProvider(tempDir)
+}
+
+func TestDiscoverTools(t *testing.T) {
+	cp := writeToolFiles(t, map[string]string{
+		"checklist.md": `---
+name: checklist
+description: Release checklist
+kind: template
+input_schema:
+  type: object
+  properties:
+    service:
+      type: string
+  required: [service]
+---
+Release checklist for {{.service}}:
+- Tests pass
+`,
+		"ops/lookup.md": `---
+name: lookup
+description: Search runbooks
+kind: search
+uri_prefix: acdc://runbooks/
+limit: 5
+---
+The body of non-template tools is ignored.
+`,
+		"notes.txt": "ignored",
+	})
+
+	defs, err := DiscoverTools(cp, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, defs, 2)
+
+	byName := map[string]ToolDefinition{}
+	for _, d := range defs {
+		byName[d.Name] = d
+	}
+
+	checklist := byName["checklist"]
+	assert.Equal(t, domain.ToolKindTemplate, checklist.Kind)
+	assert.Equal(t, []string{"service"}, checklist.InputSchema.Required)
+	assert.Equal(t, filepath.Join(cp.ToolsDir, "checklist.md"), checklist.FilePath)
+
+	lookup := byName["lookup"]
+	assert.Equal(t, domain.ToolKindSearch, lookup.Kind)
+	assert.Equal(t, "acdc://runbooks/", lookup.URIPrefix)
+	assert.Equal(t, 5, lookup.Limit)
+}
+
+func TestDiscoverTools_NoDirectory(t *testing.T) {
+	defs, err := DiscoverTools(content.NewContentProvider(t.TempDir()), nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, defs)
+}
+
+func TestDiscoverToolsWithIssues(t *testing.T) {
+	declared, err := FromMetadata([]domain.ToolMetadata{
+		{Name: "declared", Description: "d", Kind: domain.ToolKindTemplate, Template: "x"},
+	})
+	require.NoError(t, err)
+
+	cp := writeToolFiles(t, map[string]string{
+		"a-valid.md":        "---\nname: valid\ndescription: d\nkind: template\n---\nOK",
+		"b-duplicate.md":    "---\nname: valid\ndescription: d\nkind: template\n---\nOK",
+		"c-declared.md":     "---\nname: declared\ndescription: d\nkind: template\n---\nOK",
+		"d-missing.md":      "---\nname: missing\n---\nOK",
+		"e-kind.md":         "---\nname: kind\ndescription: d\nkind: exec\n---\nOK",
+		"f-template.md":     "---\nname: template\ndescription: d\nkind: template\n---\n{{.x",
+		"g-frontmatter.md":  "---\nname: [broken\n---\nOK",
+		"h-builtin.md":      "---\nname: read\ndescription: d\nkind: read\nuri: acdc://x\n---\n",
+		"i-renamed.md":      "---\nname: docs\ndescription: d\nkind: template\n---\nOK",
+		"j-invalid-name.md": "---\nname: my tool\ndescription: d\nkind: template\n---\nOK",
+	})
+
+	defs, issues, err := DiscoverToolsWithIssues(cp, declared, []string{"search", "docs", "read_many", "browse"})
+	require.NoError(t, err)
+	require.Len(t, defs, 1)
+	assert.Equal(t, "valid", defs[0].Name)
+
+	rules := map[string]string{}
+	for _, issue := range issues {
+		assert.Equal(t, domain.SeverityError, issue.Severity)
+		rules[filepath.Base(issue.File)] = issue.Rule
+	}
+	assert.Equal(t, map[string]string{
+		"b-duplicate.md":    domain.RuleDuplicateTool,
+		"c-declared.md":     domain.RuleDuplicateTool,
+		"d-missing.md":      domain.RuleRequiredField,
+		"e-kind.md":         domain.RuleFrontmatter,
+		"f-template.md":     domain.RuleTemplate,
+		"g-frontmatter.md":  domain.RuleFrontmatter,
+		"h-builtin.md":      domain.RuleFrontmatter,
+		"i-renamed.md":      domain.RuleDuplicateTool,
+		"j-invalid-name.md": domain.RuleFrontmatter,
+	}, rules)
+
+	for _, issue := range issues {
+		switch filepath.Base(issue.File) {
+		case "c-declared.md":
+			assert.Contains(t, issue.Message, "already used by mcp-metadata.yaml")
+		case "i-renamed.md":
+			assert.Contains(t, issue.Message, "already used by a built-in tool")
+		case "j-invalid-name.md":
+			assert.Contains(t, issue.Message, "invalid tool name")
+		}
+	}
+}
+
+func TestDiscoverTools_DisabledAndAnnotated(t *testing.T) {
+	cp := writeToolFiles(t, map[string]string{
+		"disabled.md":  "---\nname: disabled\ndescription: d\nkind: template\nenabled: false\n---\n{{.broken",
+		"annotated.md": "---\nname: annotated\ndescription: d\nkind: template\nannotations:\n  title: Annotated\n  idempotentHint: false\n---\nOK",
+	})
+
+	defs, issues, err := DiscoverToolsWithIssues(cp, nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, issues, "disabled tools are not validated")
+	require.Len(t, defs, 1)
+	assert.Equal(t, "annotated", defs[0].Name)
+	require.NotNil(t, defs[0].Annotations)
+	assert.Equal(t, "Annotated", defs[0].Annotations.Title)
+	require.NotNil(t, defs[0].Annotations.IdempotentHint)
+	assert.False(t, *defs[0].Annotations.IdempotentHint)
+}
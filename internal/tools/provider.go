@@ -0,0 +1,148 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+	"github.com/sha1n/mcp-acdc-server/internal/prompts"
+	"github.com/sha1n/mcp-acdc-server/internal/search"
+)
+
+// ToolProvider runs custom tools
+type ToolProvider struct {
+	definitions []ToolDefinition
+	nameMap     map[string]ToolDefinition
+	resources   prompts.Resources
+	searcher    search.Searcher
+}
+
+// Option configures a ToolProvider
+type Option func(*ToolProvider)
+
+// WithResources sets the resources read by read tools and template functions
+func WithResources(resources prompts.Resources) Option {
+	return func(p *ToolProvider) {
+		p.resources = resources
+	}
+}
+
+// WithSearcher sets the searcher used by search tools and template functions
+func WithSearcher(searcher search.Searcher) Option {
+	return func(p *ToolProvider) {
+		p.searcher = searcher
+	}
+}
+
+// NewToolProvider creates a new tool provider
+func NewToolProvider(definitions []ToolDefinition, opts ...Option) *ToolProvider {
+	nameMap := make(map[string]ToolDefinition)
+	for _, d := range definitions {
+		nameMap[d.Name] = d
+	}
+	p := &ToolProvider{
+		definitions: definitions,
+		nameMap:     nameMap,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// ListTools lists all custom tools
+func (p *ToolProvider) ListTools() []ToolDefinition {
+	return p.definitions
+}
+
+// CallTool runs a custom tool by name with arguments and returns its text result
+func (p *ToolProvider) CallTool(name string, arguments map[string]any) (string, error) {
+	defn, ok := p.nameMap[name]
+	if !ok {
+		return "", fmt.Errorf("unknown tool: %s", name)
+	}
+
+	text, err := p.render(defn, StringArguments(arguments))
+	if err != nil {
+		return "", err
+	}
+
+	switch defn.Kind {
+	case domain.ToolKindSearch:
+		return p.search(defn, strings.TrimSpace(text))
+	case domain.ToolKindRead:
+		return p.read(strings.TrimSpace(text))
+	}
+	return text, nil
+}
+
+// render executes the tool template with the content functions bound to this provider
+func (p *ToolProvider) render(defn ToolDefinition, arguments map[string]string) (string, error) {
+	tmpl, err := defn.Template.Clone()
+	if err != nil {
+		return "", fmt.Errorf("failed to prepare tool template: %w", err)
+	}
+	tmpl.Funcs(prompts.NewFuncMap(p.resources, p.searcher))
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, arguments); err != nil {
+		return "", fmt.Errorf("failed to execute tool template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+func (p *ToolProvider) search(defn ToolDefinition, query string) (string, error) {
+	if p.searcher == nil {
+		return "", fmt.Errorf("cannot search for %q: search is not available", query)
+	}
+	if query == "" {
+		return "", fmt.Errorf("search query is empty")
+	}
+
+	opts := &search.SearchOptions{URIPrefix: defn.URIPrefix}
+	if defn.Limit > 0 {
+		opts.Limit = &defn.Limit
+	}
+	results, err := p.searcher.Search(query, opts)
+	if err != nil {
+		return "", err
+	}
+	return search.FormatResults(query, results), nil
+}
+
+func (p *ToolProvider) read(uri string) (string, error) {
+	if p.resources == nil {
+		return "", fmt.Errorf("cannot read resource %s: no resources available", uri)
+	}
+	return p.resources.ReadResource(uri)
+}
+
+// StringArguments converts JSON tool arguments to the string values templates
+// are rendered with. Numbers and booleans are formatted, other values are
+// encoded as JSON.
+func StringArguments(arguments map[string]any) map[string]string {
+	values := make(map[string]string, len(arguments))
+	for k, v := range arguments {
+		switch val := v.(type) {
+		case string:
+			values[k] = val
+		case float64:
+			values[k] = strconv.FormatFloat(val, 'f', -1, 64)
+		case bool:
+			values[k] = strconv.FormatBool(val)
+		case nil:
+			values[k] = ""
+		default:
+			data, err := json.Marshal(v)
+			if err != nil {
+				values[k] = fmt.Sprint(v)
+				continue
+			}
+			values[k] = string(data)
+		}
+	}
+	return values
+}
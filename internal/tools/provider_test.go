@@ -0,0 +1,152 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sha1n/mcp-acdc-server/internal/domain"
+	"github.com/sha1n/mcp-acdc-server/internal/search"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticResources map[string]string
+
+func (r staticResources) ListResources() []mcp.Resource {
+	var list []mcp.Resource
+	for uri := range r {
+		list = append(list, mcp.Resource{URI: uri})
+	}
+	return list
+}
+
+func (r staticResources) ReadResource(uri string) (string, error) {
+	if text, ok := r[uri]; ok {
+		return text, nil
+	}
+	return "", errors.New("unknown resource: " + uri)
+}
+
+type stubSearcher struct {
+	results []search.SearchResult
+	query   string
+	opts    *search.SearchOptions
+}
+
+func (s *stubSearcher) Search(query string, opts *search.SearchOptions) ([]search.SearchResult, error) {
+	s.query = query
+	s.opts = opts
+	return s.results, nil
+}
+
+func (s *stubSearcher) Index(ctx context.Context, docs <-chan domain.Document) error { return nil }
+
+func (s *stubSearcher) Close() {}
+
+func newTestProvider(t *testing.T, searcher search.Searcher) *ToolProvider {
+	t.Helper()
+	defs, err := FromMetadata([]domain.ToolMetadata{
+		{Name: "checklist", Description: "d", Kind: domain.ToolKindTemplate,
+			Template: "Checklist for {{.service}} ({{.count}} items, strict={{.strict}}):\n{{section \"acdc://guides/release\" \"Checks\"}}"},
+		{Name: "lookup", Description: "d", Kind: domain.ToolKindSearch, URIPrefix: "acdc://runbooks/", Limit: 2},
+		{Name: "scoped", Description: "d", Kind: domain.ToolKindSearch, Query: "{{.service}} outage"},
+		{Name: "runbook", Description: "d", Kind: domain.ToolKindRead, URI: " acdc://runbooks/{{.service}} "},
+	})
+	require.NoError(t, err)
+	return NewToolProvider(defs,
+		WithResources(staticResources{
+			"acdc://guides/release": "# Release\n\n## Checks\n\n- Tests\n",
+			"acdc://runbooks/api":   "API runbook",
+		}),
+		WithSearcher(searcher),
+	)
+}
+
+func TestToolProvider_CallTool(t *testing.T) {
+	searcher := &stubSearcher{results: []search.SearchResult{{Name: "API", URI: "acdc://runbooks/api", Snippet: "restart"}}}
+	provider := newTestProvider(t, searcher)
+	assert.Len(t, provider.ListTools(), 4)
+
+	t.Run("Template", func(t *testing.T) {
+		text, err := provider.CallTool("checklist", map[string]any{"service": "api", "count": float64(3), "strict": true})
+		require.NoError(t, err)
+		assert.Equal(t, "Checklist for api (3 items, strict=true):\n## Checks\n\n- Tests", text)
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		text, err := provider.CallTool("lookup", map[string]any{"query": "restart"})
+		require.NoError(t, err)
+		assert.Equal(t, "restart", searcher.query)
+		assert.Equal(t, "acdc://runbooks/", searcher.opts.URIPrefix)
+		require.NotNil(t, searcher.opts.Limit)
+		assert.Equal(t, 2, *searcher.opts.Limit)
+		assert.Contains(t, text, "- [API](acdc://runbooks/api): restart")
+	})
+
+	t.Run("SearchWithQueryTemplate", func(t *testing.T) {
+		_, err := provider.CallTool("scoped", map[string]any{"service": "billing"})
+		require.NoError(t, err)
+		assert.Equal(t, "billing outage", searcher.query)
+		assert.Nil(t, searcher.opts.Limit, "server default applies without a limit")
+	})
+
+	t.Run("EmptyQuery", func(t *testing.T) {
+		_, err := provider.CallTool("lookup", map[string]any{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "search query is empty")
+	})
+
+	t.Run("Read", func(t *testing.T) {
+		text, err := provider.CallTool("runbook", map[string]any{"service": "api"})
+		require.NoError(t, err)
+		assert.Equal(t, "API runbook", text)
+	})
+
+	t.Run("ReadUnknownResource", func(t *testing.T) {
+		_, err := provider.CallTool("runbook", map[string]any{"service": "db"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown resource: acdc://runbooks/db")
+	})
+
+	t.Run("UnknownTool", func(t *testing.T) {
+		_, err := provider.CallTool("missing", nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown tool: missing")
+	})
+}
+
+func TestToolProvider_WithoutServices(t *testing.T) {
+	defs, err := FromMetadata([]domain.ToolMetadata{
+		{Name: "lookup", Description: "d", Kind: domain.ToolKindSearch},
+		{Name: "runbook", Description: "d", Kind: domain.ToolKindRead, URI: "acdc://x"},
+	})
+	require.NoError(t, err)
+	provider := NewToolProvider(defs)
+
+	_, err = provider.CallTool("lookup", map[string]any{"query": "x"})
+	assert.ErrorContains(t, err, "search is not available")
+
+	_, err = provider.CallTool("runbook", nil)
+	assert.ErrorContains(t, err, "no resources available")
+}
+
+func TestStringArguments(t *testing.T) {
+	values := StringArguments(map[string]any{
+		"s":    "text",
+		"n":    float64(2.5),
+		"i":    float64(10),
+		"b":    false,
+		"null": nil,
+		"list": []any{"a", "b"},
+	})
+	assert.Equal(t, map[string]string{
+		"s":    "text",
+		"n":    "2.5",
+		"i":    "10",
+		"b":    "false",
+		"null": "",
+		"list": `["a","b"]`,
+	}, values)
+}
@@ -0,0 +1,41 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sha1n/mcp-acdc-server/tests/integration/testkit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAliasesIntegration verifies that aliases and redirects resolve to the
+// resource that replaced them and are not listed
+func TestAliasesIntegration(t *testing.T) {
+	client := testkit.NewStdioTestClient(t, &testkit.ContentDirOptions{
+		Resources: map[string]string{
+			"guides/setup.md": "---\nname: Setup\ndescription: Setup guide\naliases: [setup]\n---\nSetup body",
+			"_redirects.yaml": "getting-started/setup: guides/setup\n",
+		},
+	})
+	defer client.Close()
+
+	ctx := context.Background()
+
+	listed, err := client.ListResources(ctx)
+	require.NoError(t, err)
+	require.Len(t, listed.Resources, 1)
+	assert.Equal(t, "acdc://guides/setup", listed.Resources[0].URI)
+
+	for _, alias := range []string{"acdc://setup", "acdc://getting-started/setup"} {
+		read, err := client.ReadResource(ctx, alias)
+		require.NoError(t, err, alias)
+		require.Len(t, read.Contents, 1)
+		assert.Equal(t, "acdc://guides/setup", read.Contents[0].URI)
+		assert.Equal(t, "Setup body", read.Contents[0].Text)
+		assert.Equal(t, "acdc://guides/setup", read.Contents[0].Meta["canonicalUri"])
+	}
+
+	_, err = client.ReadResource(ctx, "acdc://unknown")
+	assert.Error(t, err)
+}
@@ -0,0 +1,53 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sha1n/mcp-acdc-server/tests/integration/testkit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAttachmentsIntegration verifies that attachments are listed and served
+// as blobs, and that their extracted text is searchable and readable by tools
+func TestAttachmentsIntegration(t *testing.T) {
+	client := testkit.NewStdioTestClient(t, &testkit.ContentDirOptions{
+		Resources: map[string]string{
+			"runbook.md":           "---\nname: Runbook\ndescription: Incident runbook\nattachments:\n  - escalation.html\n  - path: diagram.png\n    name: Diagram\n---\nSee attachments.",
+			"escalation.html":      "<html><body><p>Page the duty manager</p></body></html>",
+			"diagram.png":          "\x89PNG\r\n",
+			"unattached-image.png": "\x89PNG\r\n",
+		},
+	})
+	defer client.Close()
+
+	ctx := context.Background()
+
+	listed, err := client.ListResources(ctx)
+	require.NoError(t, err)
+	mimeTypes := make(map[string]string)
+	for _, res := range listed.Resources {
+		mimeTypes[res.URI] = res.MIMEType
+	}
+	assert.Equal(t, map[string]string{
+		"acdc://runbook":         "text/markdown",
+		"acdc://escalation.html": "text/html",
+		"acdc://diagram.png":     "image/png",
+	}, mimeTypes)
+
+	read, err := client.ReadResource(ctx, "acdc://diagram.png")
+	require.NoError(t, err)
+	require.Len(t, read.Contents, 1)
+	assert.Equal(t, "image/png", read.Contents[0].MIMEType)
+	assert.Equal(t, []byte("\x89PNG\r\n"), read.Contents[0].Blob)
+
+	result, err := client.CallTool(ctx, "search", map[string]any{"query": "duty manager"})
+	require.NoError(t, err)
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "acdc://escalation.html")
+
+	result, err = client.CallTool(ctx, "read", map[string]any{"uri": "acdc://escalation.html"})
+	require.NoError(t, err)
+	assert.Equal(t, "Page the duty manager", result.Content[0].(*mcp.TextContent).Text)
+}
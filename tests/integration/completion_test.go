@@ -0,0 +1,69 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sha1n/mcp-acdc-server/tests/integration/testkit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompletion(t *testing.T) {
+	metadata := testkit.DefaultMetadata() + `resource_templates:
+  - uri_template: acdc://runbooks/{service}
+    name: Service runbook
+    description: Runbook for a service
+`
+	client := testkit.NewStdioTestClient(t, &testkit.ContentDirOptions{
+		Metadata: metadata,
+		Resources: map[string]string{
+			"runbooks/payments.md": "---\nname: Payments\ndescription: Payments runbook\n---\nRestart the payments pods.",
+			"runbooks/search.md":   "---\nname: Search\ndescription: Search runbook\n---\nRebuild the search index.",
+		},
+		Prompts: map[string]string{
+			"check-config.md": `---
+name: check-config
+description: Check a configuration setting
+arguments:
+  - name: setting
+    description: Setting to check
+    completion:
+      values: [timeout, retries, threads]
+  - name: runbook
+    description: Runbook to follow
+    completion:
+      uri_prefix: acdc://runbooks/
+  - name: topic
+    description: Related topic
+    completion:
+      search: true
+---
+Check {{.setting}}`,
+		},
+	})
+	defer client.Close()
+
+	ctx := context.Background()
+	assert.NotNil(t, client.InitializeResult().Capabilities.Completions, "should advertise completions capability")
+
+	promptRef := &mcp.CompleteReference{Type: "ref/prompt", Name: "check-config"}
+
+	result, err := client.Complete(ctx, promptRef, "setting", "t")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"timeout", "threads"}, result.Completion.Values)
+
+	result, err = client.Complete(ctx, promptRef, "runbook", "pay")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acdc://runbooks/payments"}, result.Completion.Values)
+
+	result, err = client.Complete(ctx, promptRef, "topic", "index")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acdc://runbooks/search"}, result.Completion.Values)
+
+	templateRef := &mcp.CompleteReference{Type: "ref/resource", URI: "acdc://runbooks/{service}"}
+	result, err = client.Complete(ctx, templateRef, "service", "")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"payments", "search"}, result.Completion.Values)
+}
@@ -0,0 +1,38 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sha1n/mcp-acdc-server/tests/integration/testkit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIncludeIntegration verifies that include directives are expanded when
+// resources are read and indexed, and that snippets are not listed
+func TestIncludeIntegration(t *testing.T) {
+	client := testkit.NewStdioTestClient(t, &testkit.ContentDirOptions{
+		Resources: map[string]string{
+			"ops/runbook.md":          "---\nname: Runbook\ndescription: Incident runbook\n---\n# Runbook\n\n{{< include \"/_includes/escalation.md\" >}}",
+			"_includes/escalation.md": "Escalate to the duty manager.",
+		},
+	})
+	defer client.Close()
+
+	ctx := context.Background()
+
+	listed, err := client.ListResources(ctx)
+	require.NoError(t, err)
+	require.Len(t, listed.Resources, 1)
+	assert.Equal(t, "acdc://ops/runbook", listed.Resources[0].URI)
+
+	read, err := client.ReadResource(ctx, "acdc://ops/runbook")
+	require.NoError(t, err)
+	assert.Equal(t, "# Runbook\n\nEscalate to the duty manager.", read.Contents[0].Text)
+
+	result, err := client.CallTool(ctx, "search", map[string]any{"query": "duty manager"})
+	require.NoError(t, err)
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "acdc://ops/runbook")
+}
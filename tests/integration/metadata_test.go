@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/sha1n/mcp-acdc-server/tests/integration/testkit"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -31,6 +32,54 @@ tools: []
 	// Verify server info
 	assert.Equal(t, "My Custom Server", initResult.ServerInfo.Name, "server name should match metadata")
 	assert.Equal(t, "2.5.0", initResult.ServerInfo.Version, "server version should match metadata")
+	assert.Equal(t, "Custom server instructions for testing", initResult.Instructions, "instructions should match metadata")
+}
+
+// TestInitializeReturnsPlainInstructionsAsIs verifies that instructions are
+// not rendered as a template unless they opt in
+func TestInitializeReturnsPlainInstructionsAsIs(t *testing.T) {
+	metadata := `server:
+  name: Docs
+  version: 1.0.0
+  instructions: "Prompts use Go templates, e.g. {{.topic}} and {{if .x}}."
+tools: []
+`
+	client := testkit.NewStdioTestClient(t, &testkit.ContentDirOptions{
+		Metadata: metadata,
+	})
+	defer client.Close()
+
+	initResult := client.InitializeResult()
+	require.NotNil(t, initResult)
+	assert.Equal(t, "Prompts use Go templates, e.g. {{.topic}} and {{if .x}}.", initResult.Instructions)
+}
+
+// TestInitializeReturnsRenderedInstructions verifies that templated
+// instructions are rendered against the discovered content
+func TestInitializeReturnsRenderedInstructions(t *testing.T) {
+	metadata := `server:
+  name: Docs
+  version: 1.0.0
+  instructions: "Areas:{{range .Areas}} {{.URI}}{{end}}. Prompts:{{range .Prompts}} {{.Name}}{{end}}."
+  instructions_template: true
+tools: []
+`
+	client := testkit.NewStdioTestClient(t, &testkit.ContentDirOptions{
+		Metadata: metadata,
+		Resources: map[string]string{
+			"guides/start.md": "---\nname: Start\ndescription: Start\n---\nContent",
+			"runbooks/db.md":  "---\nname: DB\ndescription: DB\n---\nContent",
+			"runbooks/api.md": "---\nname: API\ndescription: API\n---\nContent",
+		},
+		Prompts: map[string]string{
+			"review.md": "---\nname: review\ndescription: Review\narguments: []\n---\nHello",
+		},
+	})
+	defer client.Close()
+
+	initResult := client.InitializeResult()
+	require.NotNil(t, initResult)
+	assert.Equal(t, "Areas: acdc://guides/ acdc://runbooks/. Prompts: review.", initResult.Instructions)
 }
 
 // TestInitializeReturnsCapabilities verifies that initialize response contains
@@ -133,3 +182,51 @@ tools: []
 		}
 	}
 }
+
+// TestToolRenamingAndDisabling verifies that metadata can rename and disable
+// built-in tools and set their annotations
+func TestToolRenamingAndDisabling(t *testing.T) {
+	metadata := `server:
+  name: test-tools
+  version: 1.0.0
+  instructions: Test server
+tools:
+  - name: search
+    description: Search the docs
+    rename: docs_search
+    annotations:
+      title: Docs Search
+  - name: read
+    enabled: false
+  - name: read_many
+    enabled: false
+  - name: browse
+    enabled: false
+`
+	client := testkit.NewStdioTestClient(t, &testkit.ContentDirOptions{
+		Metadata: metadata,
+		Resources: map[string]string{
+			"test.md": "---\nname: Test\ndescription: Test\n---\nContent about widgets",
+		},
+	})
+	defer client.Close()
+
+	ctx := context.Background()
+
+	result, err := client.ListTools(ctx)
+	require.NoError(t, err)
+	require.Len(t, result.Tools, 1)
+
+	tool := result.Tools[0]
+	assert.Equal(t, "docs_search", tool.Name)
+	assert.Equal(t, "Search the docs", tool.Description)
+	require.NotNil(t, tool.Annotations)
+	assert.Equal(t, "Docs Search", tool.Annotations.Title)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	assert.True(t, tool.Annotations.IdempotentHint)
+
+	callResult, err := client.CallTool(ctx, "docs_search", map[string]any{"query": "widgets"})
+	require.NoError(t, err)
+	assert.False(t, callResult.IsError)
+	assert.Contains(t, callResult.Content[0].(*mcp.TextContent).Text, "acdc://test")
+}
@@ -0,0 +1,55 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sha1n/mcp-acdc-server/tests/integration/testkit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNonMarkdownResourcesIntegration verifies that files with sidecar or
+// manifest metadata are listed, read and searched with their MIME types
+func TestNonMarkdownResourcesIntegration(t *testing.T) {
+	spec := `{"info": {"title": "Billing API", "description": "Manage invoices"}}`
+	client := testkit.NewStdioTestClient(t, &testkit.ContentDirOptions{
+		Resources: map[string]string{
+			"guide.md":                     "---\nname: Guide\ndescription: A guide\n---\nGuide content.",
+			"specs/openapi.json":           spec,
+			"specs/openapi.json.meta.yaml": "name: Billing OpenAPI\ndescription: OpenAPI spec of the billing API\n",
+			"examples/app.yaml":            "service:\n  name: ledger\n",
+			"examples/_meta.yaml":          "app.yaml:\n  name: App Config\n  description: Example service configuration\n",
+		},
+	})
+	defer client.Close()
+
+	ctx := context.Background()
+
+	listed, err := client.ListResources(ctx)
+	require.NoError(t, err)
+	mimeTypes := make(map[string]string)
+	for _, res := range listed.Resources {
+		mimeTypes[res.URI] = res.MIMEType
+	}
+	assert.Equal(t, map[string]string{
+		"acdc://guide":              "text/markdown",
+		"acdc://specs/openapi.json": "application/json",
+		"acdc://examples/app.yaml":  "application/yaml",
+	}, mimeTypes)
+
+	read, err := client.ReadResource(ctx, "acdc://specs/openapi.json")
+	require.NoError(t, err)
+	require.Len(t, read.Contents, 1)
+	assert.Equal(t, "application/json", read.Contents[0].MIMEType)
+	assert.Equal(t, spec, read.Contents[0].Text)
+
+	result, err := client.CallTool(ctx, "search", map[string]any{"query": "invoices"})
+	require.NoError(t, err)
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "acdc://specs/openapi.json")
+
+	result, err = client.CallTool(ctx, "search", map[string]any{"query": "ledger"})
+	require.NoError(t, err)
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "acdc://examples/app.yaml")
+}
@@ -0,0 +1,49 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sha1n/mcp-acdc-server/tests/integration/testkit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestResourceAnnotationsIntegration verifies that resource annotations and
+// size are listed and match the annotations of read results
+func TestResourceAnnotationsIntegration(t *testing.T) {
+	client := testkit.NewStdioTestClient(t, &testkit.ContentDirOptions{
+		Resources: map[string]string{
+			"guide.md": "---\nname: Guide\ndescription: A guide\nannotations:\n  audience: [assistant]\n  priority: 0.9\n---\nGuide content.",
+		},
+	})
+	defer client.Close()
+
+	ctx := context.Background()
+
+	listed, err := client.ListResources(ctx)
+	require.NoError(t, err)
+	require.Len(t, listed.Resources, 1)
+
+	res := listed.Resources[0]
+	assert.Equal(t, int64(len("Guide content.")), res.Size)
+	require.NotNil(t, res.Annotations)
+	assert.Equal(t, []mcp.Role{"assistant"}, res.Annotations.Audience)
+	assert.Equal(t, 0.9, res.Annotations.Priority)
+	assert.NotEmpty(t, res.Annotations.LastModified, "last modified falls back to the file modification time")
+
+	read, err := client.ReadResource(ctx, "acdc://guide")
+	require.NoError(t, err)
+	require.Len(t, read.Contents, 1)
+	annotations, ok := read.Contents[0].Meta["annotations"].(map[string]any)
+	require.True(t, ok, "read results carry the annotations in _meta")
+	assert.Equal(t, []any{"assistant"}, annotations["audience"])
+	assert.Equal(t, 0.9, annotations["priority"])
+	assert.Equal(t, res.Annotations.LastModified, annotations["lastModified"])
+
+	result, err := client.CallTool(ctx, "read", map[string]any{"uri": "acdc://guide"})
+	require.NoError(t, err)
+	text := result.Content[0].(*mcp.TextContent)
+	assert.Equal(t, res.Annotations, text.Annotations)
+}
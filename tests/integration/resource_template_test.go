@@ -0,0 +1,83 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sha1n/mcp-acdc-server/tests/integration/testkit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newResourceTemplateTestClient(t *testing.T) *testkit.TestClient {
+	t.Helper()
+	metadata := testkit.DefaultMetadata() + `resource_templates:
+  - uri_template: acdc://runbooks/{service}
+    name: Service runbook
+    description: Runbook for a service
+`
+	return testkit.NewStdioTestClient(t, &testkit.ContentDirOptions{
+		Metadata: metadata,
+		Resources: map[string]string{
+			"runbooks/payments.md": "---\nname: Payments\ndescription: Payments runbook\n---\nRestart the payments pods.",
+			"services/_service.md": "---\nname: Service overview\ndescription: Overview of a service\nuri_template: acdc://services/{name}\n---\n# {{.name}}\n\nOwned by the {{.name}} team.",
+		},
+	})
+}
+
+func TestResourceTemplates_List(t *testing.T) {
+	client := newResourceTemplateTestClient(t)
+	defer client.Close()
+
+	result, err := client.ListResourceTemplates(context.Background())
+	require.NoError(t, err)
+
+	uriTemplates := make(map[string]string)
+	for _, tmpl := range result.ResourceTemplates {
+		uriTemplates[tmpl.URITemplate] = tmpl.Name
+	}
+	assert.Equal(t, map[string]string{
+		"acdc://runbooks/{service}": "Service runbook",
+		"acdc://services/{name}":    "Service overview",
+	}, uriTemplates)
+
+	resources, err := client.ListResources(context.Background())
+	require.NoError(t, err)
+	for _, res := range resources.Resources {
+		assert.NotEqual(t, "Service overview", res.Name, "template files should not be listed as resources")
+	}
+}
+
+func TestResourceTemplates_ReadRenderedTemplate(t *testing.T) {
+	client := newResourceTemplateTestClient(t)
+	defer client.Close()
+
+	result, err := client.ReadResource(context.Background(), "acdc://services/checkout")
+	require.NoError(t, err)
+	require.Len(t, result.Contents, 1)
+	assert.Equal(t, "acdc://services/checkout", result.Contents[0].URI)
+	assert.Equal(t, "# checkout\n\nOwned by the checkout team.", result.Contents[0].Text)
+}
+
+func TestResourceTemplates_ReadDiscoveredResource(t *testing.T) {
+	client := newResourceTemplateTestClient(t)
+	defer client.Close()
+
+	result, err := client.ReadResource(context.Background(), "acdc://runbooks/payments")
+	require.NoError(t, err)
+	require.Len(t, result.Contents, 1)
+	assert.Equal(t, "Restart the payments pods.", result.Contents[0].Text)
+
+	_, err = client.ReadResource(context.Background(), "acdc://runbooks/unknown")
+	assert.Error(t, err)
+}
+
+func TestResourceTemplates_ReadToolRendersTemplate(t *testing.T) {
+	client := newResourceTemplateTestClient(t)
+	defer client.Close()
+
+	result, err := client.CallTool(context.Background(), "read", map[string]any{"uri": "acdc://services/search"})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	require.NotEmpty(t, result.Content)
+}
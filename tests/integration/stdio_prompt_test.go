@@ -2,13 +2,16 @@ package integration
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"testing"
 
+	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/sha1n/mcp-acdc-server/tests/integration/testkit"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPromptIntegration(t *testing.T) {
@@ -128,3 +131,105 @@ Hello {{.arg1}}`
 	content := msg["content"].(map[string]interface{})
 	assert.Equal(t, "Hello ACDC", content["text"])
 }
+
+func TestMultiMessagePromptWithEmbeddedResource(t *testing.T) {
+	client := testkit.NewStdioTestClient(t, &testkit.ContentDirOptions{
+		Resources: map[string]string{
+			"standards/go.md": "---\nname: Go standards\ndescription: Go coding standards\n---\nUse gofmt.",
+		},
+		Prompts: map[string]string{
+			"review.md": "---\nname: review\ndescription: Review code\narguments: []\n---\nReview against:\n<!-- resource: acdc://standards/go -->\n<!-- message: assistant -->\nUnderstood.\n<!-- message: user -->\nGo ahead.",
+		},
+	})
+	defer client.Close()
+
+	result, err := client.GetPrompt(context.Background(), "review", nil)
+	require.NoError(t, err)
+	require.Len(t, result.Messages, 4)
+
+	assert.Equal(t, mcp.Role("user"), result.Messages[0].Role)
+	embedded, ok := result.Messages[1].Content.(*mcp.EmbeddedResource)
+	require.True(t, ok, "second message should embed a resource")
+	assert.Equal(t, "acdc://standards/go", embedded.Resource.URI)
+	assert.Equal(t, "Use gofmt.", embedded.Resource.Text)
+	assert.Equal(t, mcp.Role("assistant"), result.Messages[2].Role)
+	assert.Equal(t, "Understood.", result.Messages[2].Content.(*mcp.TextContent).Text)
+	assert.Equal(t, mcp.Role("user"), result.Messages[3].Role)
+}
+
+func TestPromptTemplateFunctions(t *testing.T) {
+	client := testkit.NewStdioTestClient(t, &testkit.ContentDirOptions{
+		Resources: map[string]string{
+			"standards/go.md": "---\nname: Go standards\ndescription: Go coding standards\n---\n# Go\n\n## Errors\n\nWrap errors with context.\n\n## Naming\n\nUse short names.",
+		},
+		Prompts: map[string]string{
+			"errors.md": `---
+name: errors
+description: Error handling review
+arguments:
+  - name: lang
+    required: false
+---
+Language: {{.lang | default "go" | upper}}
+{{section "acdc://standards/go" "Errors"}}
+{{range search "naming" 1}}See {{.URI}}{{end}}`,
+		},
+	})
+	defer client.Close()
+
+	result, err := client.GetPrompt(context.Background(), "errors", nil)
+	require.NoError(t, err)
+	require.Len(t, result.Messages, 1)
+	assert.Equal(t, "Language: GO\n## Errors\n\nWrap errors with context.\nSee acdc://standards/go", result.Messages[0].Content.(*mcp.TextContent).Text)
+}
+
+func TestPromptPartials(t *testing.T) {
+	client := testkit.NewStdioTestClient(t, &testkit.ContentDirOptions{
+		Prompts: map[string]string{
+			"_partials/review-checklist.md": "Checklist for {{.area}}: tests, docs.",
+			"review.md":                     "---\nname: review\ndescription: Review code\narguments:\n  - name: area\n---\nReview {{.area}}.\n{{template \"review-checklist\" .}}",
+		},
+	})
+	defer client.Close()
+
+	prompts, err := client.ListPrompts(context.Background())
+	require.NoError(t, err)
+	require.Len(t, prompts.Prompts, 1, "partials should not be listed")
+	assert.Equal(t, "review", prompts.Prompts[0].Name)
+
+	result, err := client.GetPrompt(context.Background(), "review", map[string]string{"area": "billing"})
+	require.NoError(t, err)
+	assert.Equal(t, "Review billing.\nChecklist for billing: tests, docs.", result.Messages[0].Content.(*mcp.TextContent).Text)
+}
+
+func TestTypedPromptArguments(t *testing.T) {
+	client := testkit.NewStdioTestClient(t, &testkit.ContentDirOptions{
+		Prompts: map[string]string{
+			"report.md": "---\nname: report\ndescription: Report\narguments:\n  - name: area\n    description: Area\n  - name: limit\n    description: Max items\n    type: int\n    default: 5\n  - name: format\n    description: Output format\n    enum: [markdown, json]\n---\nTop {{.limit}} in {{.area}} as {{.format}}",
+		},
+	})
+	defer client.Close()
+
+	prompts, err := client.ListPrompts(context.Background())
+	require.NoError(t, err)
+	require.Len(t, prompts.Prompts, 1)
+	args := prompts.Prompts[0].Arguments
+	require.Len(t, args, 3)
+	assert.Equal(t, "Max items (type: int; default: 5)", args[1].Description)
+	assert.False(t, args[1].Required, "arguments with a default are optional")
+	assert.Equal(t, "Output format (one of: markdown, json)", args[2].Description)
+
+	result, err := client.GetPrompt(context.Background(), "report", map[string]string{"area": "billing", "format": "json"})
+	require.NoError(t, err)
+	assert.Equal(t, "Top 5 in billing as json", result.Messages[0].Content.(*mcp.TextContent).Text)
+
+	_, err = client.GetPrompt(context.Background(), "report", map[string]string{"limit": "many", "format": "xml"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing required argument: area")
+	assert.Contains(t, err.Error(), "limit: must be an integer")
+	assert.Contains(t, err.Error(), "format: must be one of markdown, json")
+
+	completion, err := client.Complete(context.Background(), &mcp.CompleteReference{Type: "ref/prompt", Name: "report"}, "format", "m")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"markdown"}, completion.Completion.Values)
+}
@@ -0,0 +1,185 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sha1n/mcp-acdc-server/tests/integration/testkit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const initializeRequest = `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{},"clientInfo":{"name":"raw","version":"1.0"}}}`
+
+// postMCP sends a raw JSON-RPC message to the Streamable HTTP endpoint
+func postMCP(t *testing.T, url, sessionID, body string, headers map[string]string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	if sessionID != "" {
+		req.Header.Set("Mcp-Session-Id", sessionID)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	return resp
+}
+
+// TestStreamableHTTPClient exercises tools, resources and prompts over Streamable HTTP
+func TestStreamableHTTPClient(t *testing.T) {
+	client := testkit.NewStreamableHTTPTestClient(t, &testkit.ContentDirOptions{
+		Resources: map[string]string{
+			"guide.md": "---\nname: Deployment Guide\ndescription: How to deploy\nkeywords: [deploy]\n---\nDeployment steps.",
+		},
+		Prompts: map[string]string{
+			"greet.md": "---\nname: greet\ndescription: Greets\narguments:\n  - name: who\n    description: Who\n---\nHello {{.who}}",
+		},
+	})
+	defer client.Close()
+
+	ctx := context.Background()
+
+	tools, err := client.ListTools(ctx)
+	require.NoError(t, err)
+	assert.Len(t, tools.Tools, 4)
+
+	result, err := client.CallTool(ctx, "search", map[string]any{"query": "deploy"})
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "acdc://guide")
+
+	resources, err := client.ListResources(ctx)
+	require.NoError(t, err)
+	require.Len(t, resources.Resources, 1)
+
+	read, err := client.ReadResource(ctx, "acdc://guide")
+	require.NoError(t, err)
+	require.Len(t, read.Contents, 1)
+	assert.Contains(t, read.Contents[0].Text, "Deployment steps.")
+
+	prompt, err := client.GetPrompt(ctx, "greet", map[string]string{"who": "world"})
+	require.NoError(t, err)
+	require.Len(t, prompt.Messages, 1)
+	assert.Equal(t, "Hello world", prompt.Messages[0].Content.(*mcp.TextContent).Text)
+}
+
+// TestStreamableHTTPSessions verifies that sessions are assigned and enforced
+func TestStreamableHTTPSessions(t *testing.T) {
+	contentDir := testkit.CreateTestContentDir(t, nil)
+	flags := testkit.NewTestFlags(t, contentDir, &testkit.FlagOptions{Transport: "streamable-http"})
+	env := testkit.NewTestEnv(testkit.NewACDCService("acdc", flags))
+
+	props, err := env.Start()
+	require.NoError(t, err)
+	defer func() { _ = env.Stop() }()
+
+	url := props["acdc.baseURL"].(string) + "/mcp"
+
+	resp := postMCP(t, url, "", initializeRequest, nil)
+	_ = resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	sessionID := resp.Header.Get("Mcp-Session-Id")
+	assert.NotEmpty(t, sessionID, "initialize should assign a session")
+
+	t.Run("unknown session is rejected", func(t *testing.T) {
+		resp := postMCP(t, url, "unknown-session", `{"jsonrpc":"2.0","id":2,"method":"tools/list"}`, nil)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("session can be terminated", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodDelete, url, nil)
+		req.Header.Set("Mcp-Session-Id", sessionID)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Less(t, resp.StatusCode, 300)
+
+		resp = postMCP(t, url, sessionID, `{"jsonrpc":"2.0","id":3,"method":"tools/list"}`, nil)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+// TestTransportEndpoints verifies which endpoints each HTTP transport serves
+func TestTransportEndpoints(t *testing.T) {
+	tests := []struct {
+		transport string
+		wantSSE   bool
+		wantMCP   bool
+	}{
+		{"sse", true, false},
+		{"streamable-http", false, true},
+		{"http", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.transport, func(t *testing.T) {
+			contentDir := testkit.CreateTestContentDir(t, nil)
+			flags := testkit.NewTestFlags(t, contentDir, &testkit.FlagOptions{Transport: tt.transport})
+			env := testkit.NewTestEnv(testkit.NewACDCService("acdc", flags))
+
+			props, err := env.Start()
+			require.NoError(t, err)
+			defer func() { _ = env.Stop() }()
+
+			baseURL := props["acdc.baseURL"].(string)
+			assert.Equal(t, tt.transport, props["acdc.transport"])
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/sse", nil)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+			if tt.wantSSE {
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+				assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+			} else {
+				assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+			}
+
+			resp = postMCP(t, baseURL+"/mcp", "", initializeRequest, nil)
+			_ = resp.Body.Close()
+			if tt.wantMCP {
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+			} else {
+				assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+			}
+		})
+	}
+}
+
+// TestStreamableHTTPAuth verifies that the auth middleware protects /mcp
+func TestStreamableHTTPAuth(t *testing.T) {
+	contentDir := testkit.CreateTestContentDir(t, nil)
+	flags := testkit.NewTestFlags(t, contentDir, &testkit.FlagOptions{Transport: "http", AuthType: "apikey"})
+	_ = flags.Set("auth-api-keys", "key-A")
+
+	env := testkit.NewTestEnv(testkit.NewACDCService("acdc", flags))
+	props, err := env.Start()
+	require.NoError(t, err)
+	defer func() { _ = env.Stop() }()
+
+	url := props["acdc.baseURL"].(string) + "/mcp"
+
+	resp := postMCP(t, url, "", initializeRequest, nil)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp = postMCP(t, url, "", initializeRequest, map[string]string{"X-API-Key": "wrong"})
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp = postMCP(t, url, "", initializeRequest, map[string]string{"X-API-Key": "key-A"})
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
@@ -65,10 +65,10 @@ func (s *acdcService) Start() (map[string]any, error) {
 			Writer: s.stdoutWriter,
 		}
 	} else {
-		// For SSE, use custom handler that captures server instance
-		params.StartSSEServer = func(mcpSrv *mcp.Server, settings *config.Settings) error {
+		// For HTTP transports, use custom handler that captures server instance
+		params.StartHTTPServer = func(mcpSrv *mcp.Server, settings *config.Settings) error {
 			var err error
-			s.srv, err = app.NewSSEServer(mcpSrv, settings)
+			s.srv, err = app.NewHTTPServer(mcpSrv, settings)
 			if err != nil {
 				return err
 			}
@@ -88,7 +88,7 @@ func (s *acdcService) Start() (map[string]any, error) {
 		}, nil
 	}
 
-	// Wait for server to start by polling /sse
+	// Wait for server to start by polling /health
 	port, _ := s.flags.GetInt("port")
 	host, _ := s.flags.GetString("host")
 	if host == "" || host == "0.0.0.0" {
@@ -103,11 +103,11 @@ func (s *acdcService) Start() (map[string]any, error) {
 		case err := <-s.errChan:
 			return nil, fmt.Errorf("server exited unexpectedly: %w", err)
 		default:
-			resp, err := client.Get(baseURL + "/sse")
+			resp, err := client.Get(baseURL + app.HealthPath)
 			if err == nil {
 				_ = resp.Body.Close()
 				return map[string]any{
-					"acdc.transport": "sse",
+					"acdc.transport": transport,
 					"acdc.port":      port,
 					"acdc.host":      host,
 					"acdc.baseURL":   baseURL,
@@ -7,9 +7,10 @@ import (
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sha1n/mcp-acdc-server/internal/app"
 )
 
-// TestClient wraps an MCP ClientSession for testing via stdio or SSE transport.
+// TestClient wraps an MCP ClientSession for testing via stdio, SSE or Streamable HTTP transport.
 type TestClient struct {
 	Session    *mcp.ClientSession
 	client     *mcp.Client
@@ -74,12 +75,32 @@ func NewStdioTestClient(t testing.TB, contentOpts *ContentDirOptions) *TestClien
 // It starts the server, creates an MCP client, and connects via SSE.
 func NewSSETestClient(t testing.TB, contentOpts *ContentDirOptions) *TestClient {
 	t.Helper()
+	return newHTTPTestClient(t, contentOpts, "sse", func(baseURL string) mcp.Transport {
+		return &mcp.SSEClientTransport{Endpoint: baseURL + app.SSEPath}
+	})
+}
+
+// NewStreamableHTTPTestClient creates a test client connected to an ACDC server via
+// the Streamable HTTP transport.
+func NewStreamableHTTPTestClient(t testing.TB, contentOpts *ContentDirOptions) *TestClient {
+	t.Helper()
+	return newHTTPTestClient(t, contentOpts, "streamable-http", func(baseURL string) mcp.Transport {
+		return &mcp.StreamableClientTransport{Endpoint: baseURL + app.StreamableHTTPPath}
+	})
+}
+
+// newHTTPTestClient starts a server with the given HTTP transport and connects
+// an MCP client using the transport returned by newTransport.
+func newHTTPTestClient(t testing.TB, contentOpts *ContentDirOptions, transportType string, newTransport func(baseURL string) mcp.Transport) *TestClient {
+	t.Helper()
 
 	contentDir := CreateTestContentDir(t, contentOpts)
 
-	flags := NewTestFlags(t, contentDir, nil) // defaults to SSE
+	flags := NewTestFlags(t, contentDir, &FlagOptions{
+		Transport: transportType,
+	})
 
-	service := NewACDCService("acdc-sse-client-test", flags)
+	service := NewACDCService("acdc-"+transportType+"-client-test", flags)
 	env := NewTestEnv(service)
 
 	props, err := env.Start()
@@ -88,7 +109,6 @@ func NewSSETestClient(t testing.TB, contentOpts *ContentDirOptions) *TestClient
 	}
 
 	baseURL := props["acdc.baseURL"].(string)
-	sseURL := baseURL + "/sse"
 
 	// Create MCP client
 	client := mcp.NewClient(&mcp.Implementation{
@@ -96,20 +116,15 @@ func NewSSETestClient(t testing.TB, contentOpts *ContentDirOptions) *TestClient
 		Version: "1.0.0",
 	}, nil)
 
-	// Create SSE transport
-	transport := &mcp.SSEClientTransport{
-		Endpoint: sseURL,
-	}
-
 	// Connect client to server
-	// The SSE transport uses the context for the entire connection lifecycle,
+	// HTTP transports use the context for the entire connection lifecycle,
 	// so we must NOT cancel it until Close() is called
 	ctx, cancel := context.WithCancel(context.Background())
-	session, err := client.Connect(ctx, transport, nil)
+	session, err := client.Connect(ctx, newTransport(baseURL), nil)
 	if err != nil {
 		cancel()
 		_ = env.Stop()
-		t.Fatalf("Failed to connect SSE client: %v", err)
+		t.Fatalf("Failed to connect %s client: %v", transportType, err)
 	}
 
 	return &TestClient{
@@ -164,11 +179,24 @@ func (tc *TestClient) ReadResource(ctx context.Context, uri string) (*mcp.ReadRe
 	})
 }
 
+// ListResourceTemplates returns all resource templates from the server
+func (tc *TestClient) ListResourceTemplates(ctx context.Context) (*mcp.ListResourceTemplatesResult, error) {
+	return tc.Session.ListResourceTemplates(ctx, &mcp.ListResourceTemplatesParams{})
+}
+
 // ListPrompts returns all prompts from the server
 func (tc *TestClient) ListPrompts(ctx context.Context) (*mcp.ListPromptsResult, error) {
 	return tc.Session.ListPrompts(ctx, &mcp.ListPromptsParams{})
 }
 
+// Complete requests completion values for a prompt or resource template argument
+func (tc *TestClient) Complete(ctx context.Context, ref *mcp.CompleteReference, name, value string) (*mcp.CompleteResult, error) {
+	return tc.Session.Complete(ctx, &mcp.CompleteParams{
+		Ref:      ref,
+		Argument: mcp.CompleteParamsArgument{Name: name, Value: value},
+	})
+}
+
 // GetPrompt gets a prompt by name with arguments
 func (tc *TestClient) GetPrompt(ctx context.Context, name string, args map[string]string) (*mcp.GetPromptResult, error) {
 	return tc.Session.GetPrompt(ctx, &mcp.GetPromptParams{
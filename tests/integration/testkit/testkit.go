@@ -115,6 +115,7 @@ type ContentDirOptions struct {
 	Metadata  string            // Custom metadata YAML (uses default if empty)
 	Resources map[string]string // filename -> content (no resources if nil)
 	Prompts   map[string]string // filename -> content (no prompts if nil)
+	Tools     map[string]string // filename -> content (no tools if nil)
 }
 
 // DefaultMetadata returns the default test metadata YAML
@@ -177,6 +178,19 @@ func CreateTestContentDir(t testing.TB, opts *ContentDirOptions) string {
 		}
 	}
 
+	if opts != nil && opts.Tools != nil {
+		toolsDir := filepath.Join(contentDir, "mcp-tools")
+		for name, content := range opts.Tools {
+			path := filepath.Join(toolsDir, name)
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				t.Fatalf("Failed to create parent dir for tool %s: %v", name, err)
+			}
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to write tool %s: %v", name, err)
+			}
+		}
+	}
+
 	return contentDir
 }
 
@@ -2,6 +2,7 @@ package integration
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -10,7 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-// TestToolsListIntegration verifies that tools/list returns search and read tools
+// TestToolsListIntegration verifies that tools/list returns the built-in tools
 // with correct schemas (P-TOOL-01, P-TOOL-02)
 func TestToolsListIntegration(t *testing.T) {
 	client := testkit.NewStdioTestClient(t, &testkit.ContentDirOptions{
@@ -37,6 +38,84 @@ func TestToolsListIntegration(t *testing.T) {
 
 	assert.Contains(t, toolNames, "search", "should have search tool")
 	assert.Contains(t, toolNames, "read", "should have read tool")
+	assert.Contains(t, toolNames, "read_many", "should have read_many tool")
+	assert.Contains(t, toolNames, "browse", "should have browse tool")
+}
+
+// TestReadManyToolIntegration tests the read_many tool via tools/call
+func TestReadManyToolIntegration(t *testing.T) {
+	client := testkit.NewStdioTestClient(t, &testkit.ContentDirOptions{
+		Resources: map[string]string{
+			"go.md":     "---\nname: Go\ndescription: Go standards\n---\nUse gofmt.",
+			"python.md": "---\nname: Python\ndescription: Python standards\n---\nUse black.",
+		},
+	})
+	defer client.Close()
+
+	result, err := client.CallTool(context.Background(), "read_many", map[string]any{
+		"uris": []string{"acdc://python", "acdc://missing", "acdc://go"},
+	})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	require.Len(t, result.Content, 3)
+
+	python, ok := result.Content[0].(*mcp.EmbeddedResource)
+	require.True(t, ok, "resources are returned as embedded resources")
+	assert.Equal(t, "acdc://python", python.Resource.URI)
+	assert.Equal(t, "Use black.", python.Resource.Text)
+
+	assert.Contains(t, result.Content[1].(*mcp.TextContent).Text, "Error reading acdc://missing")
+	assert.Equal(t, "Use gofmt.", result.Content[2].(*mcp.EmbeddedResource).Resource.Text)
+}
+
+// TestBrowseToolIntegration tests the browse tool via tools/call
+func TestBrowseToolIntegration(t *testing.T) {
+	client := testkit.NewStdioTestClient(t, &testkit.ContentDirOptions{
+		Resources: map[string]string{
+			"readme.md":            "---\nname: Readme\ndescription: Start here\n---\nContent.",
+			"guides/setup.md":      "---\nname: Setup\ndescription: Install the tools\n---\nContent.",
+			"guides/api/auth.md":   "---\nname: Auth\ndescription: Authentication\n---\nContent.",
+			"guides/api/paging.md": "---\nname: Paging\ndescription: Pagination\n---\nContent.",
+		},
+	})
+	defer client.Close()
+
+	ctx := context.Background()
+
+	t.Run("tree", func(t *testing.T) {
+		result, err := client.CallTool(ctx, "browse", map[string]any{})
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		text := getTextContent(t, result)
+		assert.Contains(t, text, "- guides/ (acdc://guides/): 3 resource(s)")
+		assert.Contains(t, text, "    - [Auth](acdc://guides/api/auth): Authentication")
+		assert.Contains(t, text, "- [Readme](acdc://readme): Start here")
+	})
+
+	t.Run("prefix and depth", func(t *testing.T) {
+		result, err := client.CallTool(ctx, "browse", map[string]any{"uri_prefix": "acdc://guides/", "depth": 1})
+		require.NoError(t, err)
+
+		text := getTextContent(t, result)
+		assert.Contains(t, text, "- api/ (acdc://guides/api/): 2 resource(s)")
+		assert.Contains(t, text, "- [Setup](acdc://guides/setup)")
+		assert.NotContains(t, text, "Auth")
+		assert.NotContains(t, text, "Readme")
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		result, err := client.CallTool(ctx, "browse", map[string]any{"limit": 2})
+		require.NoError(t, err)
+
+		text := getTextContent(t, result)
+		assert.Contains(t, text, "(entries 1-2 of 6)")
+		assert.Contains(t, text, "call again with offset 2")
+
+		result, err = client.CallTool(ctx, "browse", map[string]any{"offset": 2, "limit": 10})
+		require.NoError(t, err)
+		assert.Contains(t, getTextContent(t, result), "(entries 3-6 of 6)")
+	})
 }
 
 // TestSearchToolExecution tests search tool via tools/call (TOOL-01, TOOL-02)
@@ -150,3 +229,132 @@ func getTextContent(t *testing.T, result *mcp.CallToolResult) string {
 	t.Fatal("no text content found in result")
 	return ""
 }
+
+// TestPromptToolsIntegration verifies that prompts are exposed as tools when
+// prompt tools are enabled
+func TestPromptToolsIntegration(t *testing.T) {
+	t.Setenv("ACDC_MCP_PROMPT_TOOLS", "true")
+	client := testkit.NewStdioTestClient(t, &testkit.ContentDirOptions{
+		Prompts: map[string]string{
+			"report.md": "---\nname: report\ndescription: Summarize an area\narguments:\n  - name: area\n    description: Area\n  - name: limit\n    description: Max items\n    type: int\n    default: 5\n---\nTop {{.limit}} issues in {{.area}}",
+		},
+	})
+	defer client.Close()
+
+	ctx := context.Background()
+
+	tools, err := client.ListTools(ctx)
+	require.NoError(t, err)
+	var reportTool *mcp.Tool
+	for _, tool := range tools.Tools {
+		if tool.Name == "report" {
+			reportTool = tool
+		}
+	}
+	require.NotNil(t, reportTool, "prompt should be registered as a tool")
+	assert.Equal(t, "Summarize an area", reportTool.Description)
+
+	schema, err := json.Marshal(reportTool.InputSchema)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "object",
+		"properties": {
+			"area": {"type": "string", "description": "Area"},
+			"limit": {"type": "integer", "description": "Max items", "default": 5}
+		},
+		"required": ["area"]
+	}`, string(schema))
+
+	result, err := client.CallTool(ctx, "report", map[string]any{"area": "billing"})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Equal(t, "Top 5 issues in billing", result.Content[0].(*mcp.TextContent).Text)
+
+	result, err = client.CallTool(ctx, "report", map[string]any{"area": "billing", "limit": 2})
+	require.NoError(t, err)
+	assert.Equal(t, "Top 2 issues in billing", result.Content[0].(*mcp.TextContent).Text)
+}
+
+// TestPromptToolsDisabledByDefault verifies that prompts are not exposed as
+// tools unless enabled
+func TestPromptToolsDisabledByDefault(t *testing.T) {
+	client := testkit.NewStdioTestClient(t, &testkit.ContentDirOptions{
+		Prompts: map[string]string{
+			"report.md": "---\nname: report\ndescription: Summarize an area\n---\nReport",
+		},
+	})
+	defer client.Close()
+
+	tools, err := client.ListTools(context.Background())
+	require.NoError(t, err)
+	for _, tool := range tools.Tools {
+		assert.NotEqual(t, "report", tool.Name)
+	}
+}
+
+// TestCustomToolsIntegration verifies that custom tools declared in metadata
+// and mcp-tools/ are registered and callable
+func TestCustomToolsIntegration(t *testing.T) {
+	client := testkit.NewStdioTestClient(t, &testkit.ContentDirOptions{
+		Metadata: `server:
+  name: test
+  version: "1.0"
+  instructions: test instructions
+tools:
+  - name: runbook
+    description: Read the runbook of a service
+    kind: read
+    uri: acdc://runbooks/{{.service}}
+    input_schema:
+      type: object
+      properties:
+        service:
+          type: string
+      required: [service]
+`,
+		Resources: map[string]string{
+			"runbooks/api.md":  "---\nname: API Runbook\ndescription: API\n---\nRestart the API pods.",
+			"guides/deploy.md": "---\nname: Deploy Guide\ndescription: Deploy\n---\nRestart after deploy.",
+		},
+		Tools: map[string]string{
+			"checklist.md": "---\nname: checklist\ndescription: Release checklist\nkind: template\ninput_schema:\n  properties:\n    service:\n      type: string\n---\nRelease {{.service}}:\n- Tests pass",
+			"lookup.md":    "---\nname: lookup\ndescription: Search runbooks\nkind: search\nuri_prefix: acdc://runbooks/\n---\n",
+		},
+	})
+	defer client.Close()
+
+	ctx := context.Background()
+
+	result, err := client.ListTools(ctx)
+	require.NoError(t, err)
+	toolNames := make(map[string]struct{})
+	for _, tool := range result.Tools {
+		toolNames[tool.Name] = struct{}{}
+	}
+	for _, name := range []string{"search", "read", "runbook", "checklist", "lookup"} {
+		assert.Contains(t, toolNames, name)
+	}
+
+	t.Run("template", func(t *testing.T) {
+		result, err := client.CallTool(ctx, "checklist", map[string]any{"service": "api"})
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		assert.Equal(t, "Release api:\n- Tests pass", result.Content[0].(*mcp.TextContent).Text)
+	})
+
+	t.Run("read", func(t *testing.T) {
+		result, err := client.CallTool(ctx, "runbook", map[string]any{"service": "api"})
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "Restart the API pods.")
+	})
+
+	t.Run("scoped search", func(t *testing.T) {
+		result, err := client.CallTool(ctx, "lookup", map[string]any{"query": "restart"})
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		text := result.Content[0].(*mcp.TextContent).Text
+		assert.Contains(t, text, "acdc://runbooks/api")
+		assert.NotContains(t, text, "acdc://guides/deploy")
+	})
+}